@@ -0,0 +1,320 @@
+package todoist
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/felipeornelis/todoist-go-client/pkg"
+)
+
+// MAX_BATCH_COMMANDS is the number of commands Todoist accepts per Sync
+// request. Larger batches are split.
+const MAX_BATCH_COMMANDS = 100
+
+// Batch queues Sync API commands and sends them in as few requests as
+// possible. Commands creating a resource return a temporary ID that later
+// commands of the same batch may use wherever a real ID is expected. Invalid
+// commands are not queued and return an empty ID; Commit reports them.
+type Batch struct {
+	client   Todoist
+	commands []syncCommand
+
+	// err joins the errors of the commands rejected since the last commit,
+	// and rejected counts them.
+	err      error
+	rejected int
+
+	// tempIDMapping accumulates the IDs resolved by every commit, so
+	// commands left queued by a failed commit are resolved when retried.
+	tempIDMapping map[string]string
+}
+
+func (t Todoist) NewBatch() *Batch {
+	return &Batch{client: t, tempIDMapping: map[string]string{}}
+}
+
+// Len returns the number of queued commands.
+func (b *Batch) Len() int {
+	return len(b.commands)
+}
+
+// BatchResult maps the temporary IDs of a committed batch to the real ones
+// and lists the commands Todoist rejected.
+type BatchResult struct {
+	TempIDMapping map[string]string
+	Errors        []*CommandError
+}
+
+// ID resolves a temporary ID, returning the ID unchanged when it is not one.
+func (r BatchResult) ID(id string) string {
+	if realID, ok := r.TempIDMapping[id]; ok {
+		return realID
+	}
+	return id
+}
+
+// Err joins the command errors, or returns nil if every command succeeded.
+func (r BatchResult) Err() error {
+	errs := make([]error, len(r.Errors))
+	for i, err := range r.Errors {
+		errs[i] = err
+	}
+	return errors.Join(errs...)
+}
+
+func (b *Batch) queue(commandType string, args map[string]any) string {
+	command := newSyncCommand(commandType, args)
+	b.commands = append(b.commands, command)
+	return command.UUID
+}
+
+func (b *Batch) queueAdd(commandType string, args map[string]any) string {
+	command := newSyncCommand(commandType, args)
+	command.TempID = pkg.NewUUID()
+	b.commands = append(b.commands, command)
+	return command.TempID
+}
+
+// reject records why a command was not queued, naming it by type and by the
+// position it would have had, and returns an empty ID.
+func (b *Batch) reject(commandType string, err error) string {
+	b.err = errors.Join(b.err, fmt.Errorf("%s command %d: %w", commandType, len(b.commands)+b.rejected+1, err))
+	b.rejected++
+	return ""
+}
+
+// AddProject queues a project creation and returns its temporary ID.
+func (b *Batch) AddProject(args AddProjectArgs) string {
+	if args.Name == "" {
+		return b.reject("project_add", errors.New("`name` is required"))
+	}
+
+	return b.queueAdd("project_add", compactArgs(map[string]any{
+		"name":        args.Name,
+		"parent_id":   args.ParentID,
+		"color":       args.Color,
+		"is_favorite": args.IsFavorite,
+		"view_style":  args.ViewStyle,
+	}))
+}
+
+// UpdateProject queues a project update and returns the command UUID.
+func (b *Batch) UpdateProject(id string, args UpdateProjectArgs) string {
+	if id == "" {
+		return b.reject("project_update", errors.New("ID is required"))
+	}
+
+	return b.queue("project_update", compactArgs(map[string]any{
+		"id":          id,
+		"name":        args.Name,
+		"color":       args.Color,
+		"is_favorite": args.IsFavorite,
+		"view_style":  args.ViewStyle,
+	}))
+}
+
+// AddSection queues a section creation and returns its temporary ID.
+func (b *Batch) AddSection(args AddSectionArgs) string {
+	if args.Name == "" || args.ProjectID == "" {
+		return b.reject("section_add", errors.New("`name` and `project_id` are required"))
+	}
+
+	return b.queueAdd("section_add", compactArgs(map[string]any{
+		"name":          args.Name,
+		"project_id":    args.ProjectID,
+		"section_order": args.Order,
+	}))
+}
+
+// AddTask queues a task creation and returns its temporary ID.
+func (b *Batch) AddTask(args AddTaskArgs) string {
+	if args.Content == "" {
+		return b.reject("item_add", errors.New("`Content` field is required"))
+	}
+
+	return b.queueAdd("item_add", compactArgs(map[string]any{
+		"content":         args.Content,
+		"description":     args.Description,
+		"project_id":      args.ProjectID,
+		"section_id":      args.SectionID,
+		"parent_id":       args.ParentID,
+		"child_order":     args.Order,
+		"labels":          args.Labels,
+		"priority":        args.Priority,
+		"due":             syncDueArgs(args.DueString, args.DueDate, args.DueDatetime, args.DueLang),
+		"responsible_uid": args.AssigneeID,
+		"duration":        syncDurationArgs(args.Duration, args.DurationUnit),
+	}))
+}
+
+// UpdateTask queues a task update and returns the command UUID.
+func (b *Batch) UpdateTask(id string, args UpdateTaskArgs) string {
+	if id == "" {
+		return b.reject("item_update", errors.New("ID is required"))
+	}
+
+	return b.queue("item_update", compactArgs(map[string]any{
+		"id":              id,
+		"content":         args.Content,
+		"description":     args.Description,
+		"labels":          args.Labels,
+		"priority":        args.Priority,
+		"due":             syncDueArgs(args.DueString, args.DueDate, args.DueDatetime, args.DueLang),
+		"responsible_uid": args.AssigneeID,
+		"duration":        syncDurationArgs(args.Duration, args.DurationUnit),
+	}))
+}
+
+// MoveTask queues moving a task, with its subtasks, and returns the command
+// UUID.
+func (b *Batch) MoveTask(id string, args MoveTaskArgs) string {
+	if id == "" {
+		return b.reject("item_move", errors.New("ID is required"))
+	}
+	if err := args.validate(); err != nil {
+		return b.reject("item_move", err)
+	}
+
+	return b.queue("item_move", args.commandArgs(id))
+}
+
+// CloseTask queues completing a task and returns the command UUID.
+func (b *Batch) CloseTask(id string) string {
+	if id == "" {
+		return b.reject("item_close", errors.New("ID is required"))
+	}
+
+	return b.queue("item_close", map[string]any{"id": id})
+}
+
+func (b *Batch) Commit() (BatchResult, error) {
+	return b.CommitWithContext(context.Background())
+}
+
+// CommitWithContext sends the queued commands in chunks of at most
+// MAX_BATCH_COMMANDS. If commands were rejected since the last commit, it
+// returns their errors without sending anything; rejected commands are never
+// queued, so they can be fixed and queued again before the next commit. Temporary IDs resolved by earlier chunks are replaced
+// in later ones. If a request fails, the result holds what was committed
+// so far, and the remaining commands stay queued for the next commit, which
+// still resolves the temporary IDs mapped by this one.
+func (b *Batch) CommitWithContext(ctx context.Context) (BatchResult, error) {
+	ctx = withOperation(ctx, "CommitBatch", RESOURCE_SYNC)
+
+	if err := b.err; err != nil {
+		b.err, b.rejected = nil, 0
+		return BatchResult{}, err
+	}
+
+	var result BatchResult
+
+	for len(b.commands) > 0 {
+		size := min(len(b.commands), MAX_BATCH_COMMANDS)
+		chunk := b.commands[:size]
+
+		for _, command := range chunk {
+			resolveTempIDs(command.Args, b.tempIDMapping)
+		}
+
+		response, err := b.client.runCommands(ctx, chunk...)
+		if err != nil {
+			result.TempIDMapping = b.mapping()
+			return result, err
+		}
+
+		for tempID, id := range response.TempIDMapping {
+			b.tempIDMapping[tempID] = id
+		}
+
+		for _, command := range chunk {
+			if err := response.commandError(command); err != nil {
+				result.Errors = append(result.Errors, err.(*CommandError))
+			}
+		}
+
+		b.commands = b.commands[size:]
+	}
+
+	result.TempIDMapping = b.mapping()
+
+	return result, nil
+}
+
+// mapping returns a copy of the temporary IDs resolved so far.
+func (b *Batch) mapping() map[string]string {
+	mapping := make(map[string]string, len(b.tempIDMapping))
+	for tempID, id := range b.tempIDMapping {
+		mapping[tempID] = id
+	}
+	return mapping
+}
+
+// idArgs are the command arguments that may hold a temporary ID.
+var idArgs = []string{"id", "project_id", "section_id", "parent_id"}
+
+func resolveTempIDs(args map[string]any, mapping map[string]string) {
+	for _, key := range idArgs {
+		if id, ok := args[key].(string); ok {
+			if realID, ok := mapping[id]; ok {
+				args[key] = realID
+			}
+		}
+	}
+}
+
+// compactArgs drops zero values, so optional arguments are left out of the
+// command like omitempty does for the REST API.
+func compactArgs(args map[string]any) map[string]any {
+	for key, value := range args {
+		switch v := value.(type) {
+		case string:
+			if v == "" {
+				delete(args, key)
+			}
+		case bool:
+			if !v {
+				delete(args, key)
+			}
+		case int:
+			if v == 0 {
+				delete(args, key)
+			}
+		case uint, uint8:
+			if v == uint(0) || v == uint8(0) {
+				delete(args, key)
+			}
+		case []string:
+			if v == nil {
+				delete(args, key)
+			}
+		case map[string]any:
+			if v == nil {
+				delete(args, key)
+			}
+		}
+	}
+
+	return args
+}
+
+func syncDueArgs(dueString string, dueDate *Date, dueDatetime *DateTime, dueLang string) map[string]any {
+	switch {
+	case dueString != "":
+		return compactArgs(map[string]any{"string": dueString, "lang": dueLang})
+	case dueDate != nil:
+		return map[string]any{"date": dueDate.String()}
+	case dueDatetime != nil:
+		return map[string]any{"date": dueDatetime.String()}
+	}
+
+	return nil
+}
+
+func syncDurationArgs(amount uint, unit string) map[string]any {
+	if amount == 0 {
+		return nil
+	}
+
+	return map[string]any{"amount": amount, "unit": unit}
+}
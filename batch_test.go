@@ -0,0 +1,150 @@
+package todoist
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync/atomic"
+	"testing"
+)
+
+func TestBatchCommitChunks(t *testing.T) {
+	server := newTestServer(t, nil)
+	batch := server.client().NewBatch()
+
+	projectID := batch.AddProject(AddProjectArgs{Name: "Moving"})
+	for i := 0; i < 250; i++ {
+		batch.AddTask(AddTaskArgs{Content: fmt.Sprintf("Box %d", i), ProjectID: projectID})
+	}
+
+	result, err := batch.Commit()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	requests := server.Requests()
+	if len(requests) != 3 {
+		t.Fatalf("sent %d requests, want 3", len(requests))
+	}
+
+	for i, want := range []int{100, 100, 51} {
+		commands := requests[i].commands(t)
+		if len(commands) != want {
+			t.Errorf("request %d sent %d commands, want %d", i, len(commands), want)
+		}
+
+		// Todoist resolves temporary IDs within a request, so only later
+		// chunks need the real ID.
+		want := projectID
+		if i > 0 {
+			want = "id-" + projectID
+		}
+
+		for _, command := range commands {
+			if got, ok := command.Args["project_id"]; ok && got != want {
+				t.Fatalf("request %d sent project_id %v, want %s", i, got, want)
+			}
+		}
+	}
+
+	if result.ID(projectID) != "id-"+projectID || len(result.TempIDMapping) != 251 {
+		t.Errorf("got %d mappings, project resolved to %q", len(result.TempIDMapping), result.ID(projectID))
+	}
+	if batch.Len() != 0 {
+		t.Errorf("%d commands left queued", batch.Len())
+	}
+}
+
+func TestBatchCommitResume(t *testing.T) {
+	var calls int32
+	server := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&calls, 1) == 2 {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		syncOK(w, r)
+	})
+	batch := server.client().NewBatch()
+
+	projectID := batch.AddProject(AddProjectArgs{Name: "Moving"})
+	for i := 0; i < 250; i++ {
+		batch.AddTask(AddTaskArgs{Content: fmt.Sprintf("Box %d", i), ProjectID: projectID})
+	}
+
+	result, err := batch.Commit()
+
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusInternalServerError {
+		t.Fatalf("got %v, want a 500 *APIError", err)
+	}
+	if result.ID(projectID) != "id-"+projectID {
+		t.Errorf("partial result resolves the project to %q", result.ID(projectID))
+	}
+	if batch.Len() != 151 {
+		t.Fatalf("%d commands left queued, want 151", batch.Len())
+	}
+
+	result, err = batch.Commit()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	requests := server.Requests()
+	if len(requests) != 4 {
+		t.Fatalf("sent %d requests, want 4", len(requests))
+	}
+
+	for _, request := range requests[2:] {
+		for _, command := range request.commands(t) {
+			if got := command.Args["project_id"]; got != "id-"+projectID {
+				t.Fatalf("resumed commit sent project_id %v, want the resolved ID", got)
+			}
+		}
+	}
+
+	if result.ID(projectID) != "id-"+projectID || batch.Len() != 0 {
+		t.Errorf("resumed commit resolves the project to %q with %d commands queued", result.ID(projectID), batch.Len())
+	}
+}
+
+func TestBatchRejectedCommands(t *testing.T) {
+	server := newTestServer(t, nil)
+
+	batch := server.client().NewBatch()
+	batch.AddProject(AddProjectArgs{Name: "Trip"})
+	if id := batch.AddTask(AddTaskArgs{}); id != "" {
+		t.Errorf("invalid AddTask returned ID %q", id)
+	}
+	batch.CloseTask("")
+
+	if batch.Len() != 1 {
+		t.Fatalf("%d commands queued, want 1", batch.Len())
+	}
+
+	_, err := batch.Commit()
+	if err == nil {
+		t.Fatal("want an error")
+	}
+	for _, want := range []string{"item_add command 2", "item_close command 3"} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("error %q does not name %q", err, want)
+		}
+	}
+	if n := len(server.Requests()); n != 0 {
+		t.Fatalf("sent %d requests, want none", n)
+	}
+
+	taskID := batch.AddTask(AddTaskArgs{Content: "Passport"})
+
+	result, err := batch.Commit()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.ID(taskID) == taskID {
+		t.Errorf("task %s not created", taskID)
+	}
+	if commands := server.Requests()[0].commands(t); len(commands) != 2 {
+		t.Errorf("sent %d commands, want 2", len(commands))
+	}
+}
@@ -0,0 +1,148 @@
+package cassette_test
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+	"time"
+
+	todoist "github.com/felipeornelis/todoist-go-client"
+	"github.com/felipeornelis/todoist-go-client/cassette"
+)
+
+// replay returns a client answered by the golden file, and checks on cleanup
+// that every recorded interaction was replayed.
+func replay(t *testing.T, name string) todoist.Todoist {
+	t.Helper()
+
+	recorder, err := cassette.New(filepath.Join("testdata", name), cassette.ModeReplay)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	t.Cleanup(func() {
+		if unused := recorder.Unused(); len(unused) > 0 {
+			t.Errorf("%d recorded interactions were not replayed", len(unused))
+		}
+	})
+
+	return todoist.New("token", todoist.WithTransport(recorder))
+}
+
+func TestAddTaskWire(t *testing.T) {
+	client := replay(t, "add_task.json")
+
+	task, err := client.AddTask(todoist.AddTaskArgs{
+		Content:   "Buy Milk",
+		ProjectID: "2203306141",
+		Priority:  4,
+		DueString: "tomorrow at 12:00",
+		DueLang:   "en",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if task.ID != "2995104339" || task.Due.String != "tomorrow at 12" || task.Due.Timezone != "Europe/Moscow" {
+		t.Errorf("got %+v", task)
+	}
+	if want := time.Date(2016, time.September, 1, 12, 0, 0, 0, time.UTC); !task.Due.Datetime.Equal(want) {
+		t.Errorf("Due.Datetime = %s, want %s", task.Due.Datetime, want)
+	}
+}
+
+func TestUpdateCommentWire(t *testing.T) {
+	client := replay(t, "update_comment.json")
+
+	comment, err := client.UpdateComment("2992679862", todoist.UpdateCommentArgs{Content: "Need one bottle of milk"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if comment.ID != "2992679862" || comment.TaskID != "2995104339" || comment.Content != "Need one bottle of milk" {
+		t.Errorf("got %+v", comment)
+	}
+}
+
+func TestRenameSharedLabelsWire(t *testing.T) {
+	client := replay(t, "rename_shared_labels.json")
+
+	if err := client.RenameSharedLabels(todoist.RenameSharedLabelsArgs{Name: "Food", NewName: "Groceries"}); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestSyncCommandsReplay(t *testing.T) {
+	client := replay(t, "sync_commands.json")
+
+	if err := client.MoveSection("7025", "2203306141"); err != nil {
+		t.Fatal(err)
+	}
+
+	batch := client.NewBatch()
+	projectID := batch.AddProject(todoist.AddProjectArgs{Name: "Moving"})
+	sectionID := batch.AddSection(todoist.AddSectionArgs{Name: "Kitchen", ProjectID: projectID})
+
+	result, err := batch.Commit()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := result.Err(); err != nil {
+		t.Fatal(err)
+	}
+
+	if result.ID(projectID) != "2203306141" || result.ID(sectionID) != "7025" {
+		t.Errorf("got project %s and section %s", result.ID(projectID), result.ID(sectionID))
+	}
+}
+
+func TestReplayMismatch(t *testing.T) {
+	client := replay(t, "rename_shared_labels.json")
+
+	err := client.RenameSharedLabels(todoist.RenameSharedLabelsArgs{Name: "Food", NewName: "Snacks"})
+	if !errors.Is(err, cassette.ErrNoInteraction) {
+		t.Fatalf("got %v, want ErrNoInteraction", err)
+	}
+
+	// Replay the expected request so the cleanup check passes.
+	client.RenameSharedLabels(todoist.RenameSharedLabelsArgs{Name: "Food", NewName: "Groceries"})
+}
+
+func TestRecordScrubsCredentials(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	path := filepath.Join(t.TempDir(), "delete_task.json")
+
+	recorder, err := cassette.New(path, cassette.ModeRecord)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	client := todoist.New("secret", todoist.WithBaseURL(server.URL), todoist.WithTransport(recorder))
+	if err := client.DeleteTask("2995104339"); err != nil {
+		t.Fatal(err)
+	}
+	if err := recorder.Save(); err != nil {
+		t.Fatal(err)
+	}
+
+	saved, err := cassette.New(path, cassette.ModeReplay)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	interactions := saved.Interactions()
+	if len(interactions) != 1 {
+		t.Fatalf("saved %d interactions, want 1", len(interactions))
+	}
+
+	header := interactions[0].Request.Header
+	if header.Get("Authorization") != cassette.REDACTED || header.Get("X-Request-Id") != "" {
+		t.Errorf("saved request header %v", header)
+	}
+}
@@ -0,0 +1,97 @@
+package cassette
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+// Sync API requests carry a random uuid, and a temp_id for creations, in
+// every command. Neither survives a re-run, so they are matched by position
+// and the replayed response is rewritten to use the ones just sent.
+
+// commandIDs returns the uuid and temp_id of every command of a
+// form-encoded Sync request, in order, or nil if body holds no commands.
+func commandIDs(body []byte) []string {
+	form, err := url.ParseQuery(string(body))
+	if err != nil || form.Get("commands") == "" {
+		return nil
+	}
+
+	var commands []struct {
+		UUID   string `json:"uuid"`
+		TempID string `json:"temp_id"`
+	}
+	if err := json.Unmarshal([]byte(form.Get("commands")), &commands); err != nil {
+		return nil
+	}
+
+	ids := make([]string, 0, 2*len(commands))
+	for _, command := range commands {
+		ids = append(ids, command.UUID, command.TempID)
+	}
+
+	return ids
+}
+
+// normalizeCommands replaces the command IDs of a Sync request by
+// placeholders numbered in order, wherever they appear in its commands.
+func normalizeCommands(body []byte) (url.Values, bool) {
+	ids := commandIDs(body)
+	if ids == nil {
+		return nil, false
+	}
+
+	form, _ := url.ParseQuery(string(body))
+
+	replacements := make([]string, 0, 2*len(ids))
+	for i, id := range ids {
+		if id != "" {
+			replacements = append(replacements, fmt.Sprintf("%q", id), fmt.Sprintf(`"$id%d"`, i))
+		}
+	}
+
+	commands := strings.NewReplacer(replacements...).Replace(form.Get("commands"))
+
+	var decoded any
+	if err := json.Unmarshal([]byte(commands), &decoded); err != nil {
+		return nil, false
+	}
+	normalized, _ := json.Marshal(decoded)
+	form.Set("commands", string(normalized))
+
+	return form, true
+}
+
+func equalCommands(a, b []byte) bool {
+	formA, ok := normalizeCommands(a)
+	if !ok {
+		return false
+	}
+
+	formB, ok := normalizeCommands(b)
+	if !ok {
+		return false
+	}
+
+	return formA.Encode() == formB.Encode()
+}
+
+// rewriteCommandIDs replaces the command IDs of the recorded request found
+// in a recorded response body by the ones of the request being replayed.
+func rewriteCommandIDs(responseBody string, recordedBody, body []byte) string {
+	recorded, current := commandIDs(recordedBody), commandIDs(body)
+	if recorded == nil || len(recorded) != len(current) {
+		return responseBody
+	}
+
+	replacements := make([]string, 0, 2*len(recorded))
+	for i, id := range recorded {
+		if id != "" && id != current[i] {
+			replacements = append(replacements, fmt.Sprintf("%q", id), fmt.Sprintf("%q", current[i]))
+		}
+	}
+
+	return strings.NewReplacer(replacements...).Replace(responseBody)
+}
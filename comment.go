@@ -2,22 +2,24 @@ package todoist
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
 	"io"
 	"net/http"
-
-	"github.com/felipeornelis/todoist-go-client/pkg"
 )
 
-const COMMENT_URL = BASE_URL + "/comments"
+const (
+	COMMENT_PATH = "/comments"
+	COMMENT_URL  = BASE_URL + COMMENT_PATH
+)
 
 type Comment struct {
 	ID         string            `json:"id"`
 	TaskID     string            `json:"task_id"`
 	ProjectID  string            `json:"project_id"`
-	PostedAt   string            `json:"posted_at"`
+	PostedAt   DateTime          `json:"posted_at"`
 	Content    string            `json:"content"`
 	Attachment CommentAttachment `json:"attachment,omitempty"`
 }
@@ -35,37 +37,37 @@ type GetCommentsArgs struct {
 }
 
 func (t Todoist) GetComments(args GetCommentsArgs) ([]Comment, error) {
+	return t.GetCommentsWithContext(context.Background(), args)
+}
+
+func (t Todoist) GetCommentsWithContext(ctx context.Context, args GetCommentsArgs) ([]Comment, error) {
+	ctx = withOperation(ctx, "GetComments", RESOURCE_COMMENT)
+
 	if args.ProjectID == "" && args.TaskID == "" {
 		return nil, errors.New("task_id or project_id is required")
 	}
 
-	var url string
+	var path string
 
 	if args.ProjectID == "" {
-		url = fmt.Sprintf("%s?task_id=%s", COMMENT_URL, args.TaskID)
+		path = fmt.Sprintf("%s?task_id=%s", COMMENT_PATH, args.TaskID)
 	} else {
-		url = fmt.Sprintf("%s?project_id=%s", COMMENT_URL, args.ProjectID)
+		path = fmt.Sprintf("%s?project_id=%s", COMMENT_PATH, args.ProjectID)
 	}
 
-	request, err := http.NewRequest(http.MethodGet, url, nil)
+	request, err := t.newRequest(ctx, http.MethodGet, path, nil)
 	if err != nil {
 		return nil, err
 	}
 
-	request.Header.Set("Authorization", fmt.Sprintf("Bearer %s", t.authToken))
-
-	client := &http.Client{
-		Timeout: MAX_TIMEOUT,
-	}
-
-	response, err := client.Do(request)
+	response, err := t.do(request)
 	if err != nil {
 		return nil, err
 	}
 	defer response.Body.Close()
 
 	if response.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("HTTP request failed with status code: %d", response.StatusCode)
+		return nil, newAPIError(response)
 	}
 
 	data, err := io.ReadAll(response.Body)
@@ -82,31 +84,31 @@ func (t Todoist) GetComments(args GetCommentsArgs) ([]Comment, error) {
 }
 
 func (t Todoist) GetComment(id string) (Comment, error) {
+	return t.GetCommentWithContext(context.Background(), id)
+}
+
+func (t Todoist) GetCommentWithContext(ctx context.Context, id string) (Comment, error) {
+	ctx = withOperation(ctx, "GetComment", RESOURCE_COMMENT)
+
 	if id == "" {
 		return Comment{}, errors.New("ID is required")
 	}
 
-	url := fmt.Sprintf("%s/%s", COMMENT_URL, id)
+	path := fmt.Sprintf("%s/%s", COMMENT_PATH, id)
 
-	request, err := http.NewRequest(http.MethodGet, url, nil)
+	request, err := t.newRequest(ctx, http.MethodGet, path, nil)
 	if err != nil {
 		return Comment{}, err
 	}
 
-	request.Header.Set("Authorization", fmt.Sprintf("Bearer %s", t.authToken))
-
-	client := &http.Client{
-		Timeout: MAX_TIMEOUT,
-	}
-
-	response, err := client.Do(request)
+	response, err := t.do(request)
 	if err != nil {
 		return Comment{}, err
 	}
 	defer response.Body.Close()
 
 	if response.StatusCode != http.StatusOK {
-		return Comment{}, fmt.Errorf("HTTP request failed with status code: %d", response.StatusCode)
+		return Comment{}, newAPIError(response)
 	}
 
 	data, err := io.ReadAll(response.Body)
@@ -130,6 +132,12 @@ type AddCommentArgs struct {
 }
 
 func (t Todoist) AddComment(args AddCommentArgs) (Comment, error) {
+	return t.AddCommentWithContext(context.Background(), args)
+}
+
+func (t Todoist) AddCommentWithContext(ctx context.Context, args AddCommentArgs) (Comment, error) {
+	ctx = withOperation(ctx, "AddComment", RESOURCE_COMMENT)
+
 	if args.TaskID == "" && args.ProjectID == "" {
 		return Comment{}, errors.New("task_id or project_id is required")
 	}
@@ -139,27 +147,21 @@ func (t Todoist) AddComment(args AddCommentArgs) (Comment, error) {
 		return Comment{}, err
 	}
 
-	request, err := http.NewRequest(http.MethodPost, COMMENT_URL, bytes.NewReader(bodyRequest))
+	request, err := t.newRequest(ctx, http.MethodPost, COMMENT_PATH, bytes.NewReader(bodyRequest))
 	if err != nil {
 		return Comment{}, err
 	}
 
 	request.Header.Set("Content-Type", "application/json")
-	request.Header.Set("X-Request-Id", pkg.NewUUID())
-	request.Header.Set("Authorization", fmt.Sprintf("Bearer %s", t.authToken))
 
-	client := &http.Client{
-		Timeout: MAX_TIMEOUT,
-	}
-
-	response, err := client.Do(request)
+	response, err := t.do(request)
 	if err != nil {
 		return Comment{}, err
 	}
 	defer response.Body.Close()
 
 	if response.StatusCode != http.StatusOK {
-		return Comment{}, fmt.Errorf("HTTP request failed with status code: %d", response.StatusCode)
+		return Comment{}, newAPIError(response)
 	}
 
 	data, err := io.ReadAll(response.Body)
@@ -180,6 +182,12 @@ type UpdateCommentArgs struct {
 }
 
 func (t Todoist) UpdateComment(id string, args UpdateCommentArgs) (Comment, error) {
+	return t.UpdateCommentWithContext(context.Background(), id, args)
+}
+
+func (t Todoist) UpdateCommentWithContext(ctx context.Context, id string, args UpdateCommentArgs) (Comment, error) {
+	ctx = withOperation(ctx, "UpdateComment", RESOURCE_COMMENT)
+
 	if id == "" {
 		return Comment{}, errors.New("ID is required")
 	}
@@ -189,29 +197,23 @@ func (t Todoist) UpdateComment(id string, args UpdateCommentArgs) (Comment, erro
 		return Comment{}, err
 	}
 
-	url := fmt.Sprintf("%s/%s", COMMENT_URL, id)
+	path := fmt.Sprintf("%s/%s", COMMENT_PATH, id)
 
-	request, err := http.NewRequest(http.MethodPost, url, bytes.NewReader(bodyRequest))
+	request, err := t.newRequest(ctx, http.MethodPost, path, bytes.NewReader(bodyRequest))
 	if err != nil {
 		return Comment{}, err
 	}
 
 	request.Header.Set("Content-Type", "application/json")
-	request.Header.Set("X-Request-Id", pkg.NewUUID())
-	request.Header.Set("Authorization", fmt.Sprintf("Bearer %s", t.authToken))
-
-	client := &http.Client{
-		Timeout: MAX_TIMEOUT,
-	}
 
-	response, err := client.Do(request)
+	response, err := t.do(request)
 	if err != nil {
 		return Comment{}, err
 	}
 	defer response.Body.Close()
 
 	if response.StatusCode != http.StatusOK {
-		return Comment{}, fmt.Errorf("HTTP request failed with status code: %d", response.StatusCode)
+		return Comment{}, newAPIError(response)
 	}
 
 	data, err := io.ReadAll(response.Body)
@@ -228,27 +230,27 @@ func (t Todoist) UpdateComment(id string, args UpdateCommentArgs) (Comment, erro
 }
 
 func (t Todoist) DeleteComment(id string) error {
-	url := fmt.Sprintf("%s/%s", COMMENT_URL, id)
+	return t.DeleteCommentWithContext(context.Background(), id)
+}
 
-	client := &http.Client{
-		Timeout: MAX_TIMEOUT,
-	}
+func (t Todoist) DeleteCommentWithContext(ctx context.Context, id string) error {
+	ctx = withOperation(ctx, "DeleteComment", RESOURCE_COMMENT)
+
+	path := fmt.Sprintf("%s/%s", COMMENT_PATH, id)
 
-	request, err := http.NewRequest(http.MethodDelete, url, nil)
+	request, err := t.newRequest(ctx, http.MethodDelete, path, nil)
 	if err != nil {
 		return err
 	}
 
-	request.Header.Set("Authorization", fmt.Sprintf("Bearer %s", t.authToken))
-
-	response, err := client.Do(request)
+	response, err := t.do(request)
 	if err != nil {
 		return err
 	}
 	defer response.Body.Close()
 
 	if response.StatusCode != http.StatusNoContent {
-		return fmt.Errorf("HTTP request failed with status code: %d", response.StatusCode)
+		return newAPIError(response)
 	}
 
 	return nil
@@ -0,0 +1,185 @@
+package todoist
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+const (
+	COMPLETED_TASKS_PATH = "/completed/get_all"
+
+	// DEFAULT_COMPLETED_TASKS_LIMIT and MAX_COMPLETED_TASKS_LIMIT are the
+	// default and largest page sizes of GetCompletedTasks.
+	DEFAULT_COMPLETED_TASKS_LIMIT = 30
+	MAX_COMPLETED_TASKS_LIMIT     = 200
+
+	completedTasksTimeLayout = "2006-01-02T15:04"
+)
+
+// CompletedTask is a task as it was when it was completed.
+type CompletedTask struct {
+	Task
+	CompletedAt DateTime `json:"completed_at"`
+}
+
+// GetCompletedTasksArgs filters completed tasks. Every field is optional.
+type GetCompletedTasksArgs struct {
+	ProjectID string
+	// Since and Until bound the completion time, with minute precision.
+	Since time.Time
+	Until time.Time
+	// Limit is the page size, at most MAX_COMPLETED_TASKS_LIMIT.
+	Limit  int
+	Offset int
+}
+
+func (args GetCompletedTasksArgs) form() (url.Values, error) {
+	if args.Limit < 0 || args.Limit > MAX_COMPLETED_TASKS_LIMIT {
+		return nil, fmt.Errorf("`Limit` must be between 0 and %d", MAX_COMPLETED_TASKS_LIMIT)
+	}
+	if args.Offset < 0 {
+		return nil, errors.New("`Offset` must not be negative")
+	}
+	if !args.Since.IsZero() && !args.Until.IsZero() && args.Until.Before(args.Since) {
+		return nil, errors.New("`Until` must not be before `Since`")
+	}
+
+	form := url.Values{"annotate_items": {"true"}}
+
+	if args.ProjectID != "" {
+		form.Set("project_id", args.ProjectID)
+	}
+	if !args.Since.IsZero() {
+		form.Set("since", args.Since.UTC().Format(completedTasksTimeLayout))
+	}
+	if !args.Until.IsZero() {
+		form.Set("until", args.Until.UTC().Format(completedTasksTimeLayout))
+	}
+	if args.Limit > 0 {
+		form.Set("limit", strconv.Itoa(args.Limit))
+	}
+	if args.Offset > 0 {
+		form.Set("offset", strconv.Itoa(args.Offset))
+	}
+
+	return form, nil
+}
+
+// CompletedTasksPage is one page of completed tasks, most recent first.
+type CompletedTasksPage struct {
+	Tasks []CompletedTask
+	// HasMore tells whether another page may follow, to be fetched with
+	// NextOffset as the Offset.
+	HasMore    bool
+	NextOffset int
+}
+
+type completedItem struct {
+	ID          string    `json:"id"`
+	TaskID      string    `json:"task_id"`
+	ProjectID   string    `json:"project_id"`
+	SectionID   string    `json:"section_id"`
+	Content     string    `json:"content"`
+	NoteCount   int       `json:"note_count"`
+	CompletedAt DateTime  `json:"completed_at"`
+	ItemObject  *syncItem `json:"item_object"`
+}
+
+// toCompletedTask uses the full task when Todoist annotated the completion
+// with it, and the completion's own fields otherwise.
+func (i completedItem) toCompletedTask() (CompletedTask, error) {
+	task := Task{
+		ID:           i.TaskID,
+		ProjectID:    i.ProjectID,
+		SectionID:    i.SectionID,
+		Content:      i.Content,
+		CommentCount: i.NoteCount,
+		URL:          fmt.Sprintf("https://todoist.com/showTask?id=%s", i.TaskID),
+	}
+
+	if i.ItemObject != nil {
+		var err error
+		if task, err = i.ItemObject.toTask(); err != nil {
+			return CompletedTask{}, err
+		}
+	}
+
+	task.IsCompleted = true
+
+	return CompletedTask{Task: task, CompletedAt: i.CompletedAt}, nil
+}
+
+type completedTasksResponse struct {
+	Items []completedItem `json:"items"`
+}
+
+// GetCompletedTasks returns a page of completed tasks, using the Sync API.
+func (t Todoist) GetCompletedTasks(args GetCompletedTasksArgs) (CompletedTasksPage, error) {
+	return t.GetCompletedTasksWithContext(context.Background(), args)
+}
+
+func (t Todoist) GetCompletedTasksWithContext(ctx context.Context, args GetCompletedTasksArgs) (CompletedTasksPage, error) {
+	ctx = withOperation(ctx, "GetCompletedTasks", RESOURCE_TASK)
+
+	form, err := args.form()
+	if err != nil {
+		return CompletedTasksPage{}, err
+	}
+
+	var response completedTasksResponse
+	if err := t.postSync(ctx, COMPLETED_TASKS_PATH, form, &response); err != nil {
+		return CompletedTasksPage{}, err
+	}
+
+	page := CompletedTasksPage{Tasks: make([]CompletedTask, 0, len(response.Items))}
+	for _, item := range response.Items {
+		task, err := item.toCompletedTask()
+		if err != nil {
+			return CompletedTasksPage{}, err
+		}
+		page.Tasks = append(page.Tasks, task)
+	}
+
+	limit := args.Limit
+	if limit == 0 {
+		limit = DEFAULT_COMPLETED_TASKS_LIMIT
+	}
+
+	page.NextOffset = args.Offset + len(page.Tasks)
+	page.HasMore = len(page.Tasks) >= limit
+
+	return page, nil
+}
+
+// GetAllCompletedTasks fetches every page of completed tasks matching args,
+// starting at args.Offset.
+func (t Todoist) GetAllCompletedTasks(args GetCompletedTasksArgs) ([]CompletedTask, error) {
+	return t.GetAllCompletedTasksWithContext(context.Background(), args)
+}
+
+func (t Todoist) GetAllCompletedTasksWithContext(ctx context.Context, args GetCompletedTasksArgs) ([]CompletedTask, error) {
+	ctx = withOperation(ctx, "GetAllCompletedTasks", RESOURCE_TASK)
+
+	if args.Limit == 0 {
+		args.Limit = MAX_COMPLETED_TASKS_LIMIT
+	}
+
+	var tasks []CompletedTask
+	for {
+		page, err := t.GetCompletedTasksWithContext(ctx, args)
+		if err != nil {
+			return nil, err
+		}
+
+		tasks = append(tasks, page.Tasks...)
+		if !page.HasMore {
+			return tasks, nil
+		}
+
+		args.Offset = page.NextOffset
+	}
+}
@@ -0,0 +1,183 @@
+package todoist
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"reflect"
+	"strconv"
+	"testing"
+	"time"
+)
+
+func TestCompletedTasksForm(t *testing.T) {
+	paris := time.FixedZone("CEST", 2*60*60)
+
+	tests := []struct {
+		name    string
+		args    GetCompletedTasksArgs
+		want    url.Values
+		wantErr bool
+	}{
+		{"defaults", GetCompletedTasksArgs{}, url.Values{"annotate_items": {"true"}}, false},
+		{
+			"filters",
+			GetCompletedTasksArgs{
+				ProjectID: "2203306141",
+				Since:     time.Date(2026, time.October, 1, 0, 30, 59, 0, paris),
+				Until:     time.Date(2026, time.October, 17, 12, 0, 0, 0, time.UTC),
+				Limit:     MAX_COMPLETED_TASKS_LIMIT,
+				Offset:    400,
+			},
+			url.Values{
+				"annotate_items": {"true"},
+				"project_id":     {"2203306141"},
+				"since":          {"2026-09-30T22:30"},
+				"until":          {"2026-10-17T12:00"},
+				"limit":          {"200"},
+				"offset":         {"400"},
+			},
+			false,
+		},
+		{"negative limit", GetCompletedTasksArgs{Limit: -1}, nil, true},
+		{"limit too large", GetCompletedTasksArgs{Limit: MAX_COMPLETED_TASKS_LIMIT + 1}, nil, true},
+		{"negative offset", GetCompletedTasksArgs{Offset: -1}, nil, true},
+		{"until before since", GetCompletedTasksArgs{Since: time.Now(), Until: time.Now().Add(-time.Hour)}, nil, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.args.form()
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("err = %v, want error %t", err, tt.wantErr)
+			}
+			if !tt.wantErr && !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("form = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGetCompletedTasksDecode(t *testing.T) {
+	fixture, err := os.ReadFile("testdata/completed.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	server := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Write(fixture)
+	})
+
+	page, err := server.client().GetCompletedTasks(GetCompletedTasksArgs{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if request := server.Requests()[0]; request.Method != http.MethodPost || request.Path != COMPLETED_TASKS_PATH {
+		t.Errorf("request = %s %s, want POST %s", request.Method, request.Path, COMPLETED_TASKS_PATH)
+	}
+
+	if len(page.Tasks) != 2 || page.HasMore || page.NextOffset != 2 {
+		t.Fatalf("page = %+v", page)
+	}
+
+	annotated := page.Tasks[0]
+	if annotated.ID != "2995104339" || annotated.Description != "Bring two photos" || annotated.Priority != 3 ||
+		!reflect.DeepEqual(annotated.Labels, []string{"errands"}) || annotated.Due.Date != NewDate(2026, time.October, 16) ||
+		!annotated.IsCompleted || !annotated.CompletedAt.Equal(time.Date(2026, time.October, 16, 18, 4, 11, 0, time.UTC)) {
+		t.Errorf("annotated task = %+v", annotated)
+	}
+
+	bare := page.Tasks[1]
+	want := CompletedTask{
+		Task: Task{
+			ID:          "2995104340",
+			ProjectID:   "2203306142",
+			Content:     "Water plants",
+			IsCompleted: true,
+			URL:         "https://todoist.com/showTask?id=2995104340",
+		},
+		CompletedAt: NewDateTime(time.Date(2026, time.October, 15, 7, 45, 0, 0, time.UTC)),
+	}
+	if !bare.CompletedAt.Equal(want.CompletedAt.Time) {
+		t.Errorf("bare completion at %s, want %s", bare.CompletedAt, want.CompletedAt)
+	}
+	bare.CompletedAt = want.CompletedAt
+	if !reflect.DeepEqual(bare, want) {
+		t.Errorf("bare completion = %+v, want %+v", bare, want)
+	}
+}
+
+// completedPages serves total completions, paginated like Todoist.
+func completedPages(total int) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		r.ParseForm()
+
+		offset, _ := strconv.Atoi(r.PostForm.Get("offset"))
+		limit, _ := strconv.Atoi(r.PostForm.Get("limit"))
+		if limit == 0 {
+			limit = DEFAULT_COMPLETED_TASKS_LIMIT
+		}
+
+		items := []map[string]any{}
+		for i := offset; i < total && i < offset+limit; i++ {
+			items = append(items, map[string]any{
+				"id":           strconv.Itoa(i),
+				"task_id":      fmt.Sprintf("task-%d", i),
+				"content":      fmt.Sprintf("Task %d", i),
+				"completed_at": "2026-10-16T18:04:11Z",
+			})
+		}
+
+		json.NewEncoder(w).Encode(map[string]any{"items": items})
+	}
+}
+
+func TestGetCompletedTasksPages(t *testing.T) {
+	server := newTestServer(t, completedPages(45))
+	client := server.client()
+
+	first, err := client.GetCompletedTasks(GetCompletedTasksArgs{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(first.Tasks) != DEFAULT_COMPLETED_TASKS_LIMIT || !first.HasMore || first.NextOffset != DEFAULT_COMPLETED_TASKS_LIMIT {
+		t.Fatalf("first page: %d tasks, HasMore %t, NextOffset %d", len(first.Tasks), first.HasMore, first.NextOffset)
+	}
+
+	second, err := client.GetCompletedTasks(GetCompletedTasksArgs{Offset: first.NextOffset})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(second.Tasks) != 15 || second.HasMore || second.NextOffset != 45 {
+		t.Fatalf("second page: %d tasks, HasMore %t, NextOffset %d", len(second.Tasks), second.HasMore, second.NextOffset)
+	}
+}
+
+func TestGetAllCompletedTasks(t *testing.T) {
+	server := newTestServer(t, completedPages(450))
+
+	tasks, err := server.client().GetAllCompletedTasks(GetCompletedTasksArgs{Offset: 10})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(tasks) != 440 || tasks[0].ID != "task-10" || tasks[439].ID != "task-449" {
+		t.Fatalf("got %d tasks", len(tasks))
+	}
+
+	var offsets []string
+	for _, request := range server.Requests() {
+		form, _ := url.ParseQuery(request.Body)
+		offsets = append(offsets, form.Get("offset"))
+		if form.Get("limit") != strconv.Itoa(MAX_COMPLETED_TASKS_LIMIT) {
+			t.Errorf("limit = %q, want %d", form.Get("limit"), MAX_COMPLETED_TASKS_LIMIT)
+		}
+	}
+	// The short third page ends the loop.
+	if want := []string{"10", "210", "410"}; !reflect.DeepEqual(offsets, want) {
+		t.Errorf("offsets = %v, want %v", offsets, want)
+	}
+}
@@ -0,0 +1,156 @@
+package todoist
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+	"time"
+)
+
+// StaticToken is a TokenSource always returning the same token, as used by
+// New.
+type StaticToken string
+
+func (s StaticToken) Token(context.Context) (string, error) {
+	return string(s), nil
+}
+
+// TokenSourceFunc adapts a function, e.g. a secret manager lookup, to a
+// TokenSource. Wrap it with CacheToken to avoid calling it on every request.
+type TokenSourceFunc func(ctx context.Context) (string, error)
+
+func (f TokenSourceFunc) Token(ctx context.Context) (string, error) {
+	return f(ctx)
+}
+
+// EnvToken reads the token from the environment variable on every request,
+// so it follows changes made to the process environment.
+func EnvToken(name string) TokenSource {
+	return TokenSourceFunc(func(context.Context) (string, error) {
+		token := strings.TrimSpace(os.Getenv(name))
+		if token == "" {
+			return "", fmt.Errorf("environment variable %s is not set", name)
+		}
+
+		return token, nil
+	})
+}
+
+// FileTokenSource reads the token from a file, such as a mounted secret, and
+// reloads it whenever the file changes.
+type FileTokenSource struct {
+	path string
+
+	mu      sync.Mutex
+	token   string
+	modTime time.Time
+	size    int64
+}
+
+func FileToken(path string) *FileTokenSource {
+	return &FileTokenSource{path: path}
+}
+
+func (s *FileTokenSource) Token(context.Context) (string, error) {
+	info, err := os.Stat(s.path)
+	if err != nil {
+		return "", err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.token != "" && info.ModTime().Equal(s.modTime) && info.Size() == s.size {
+		return s.token, nil
+	}
+
+	data, err := os.ReadFile(s.path)
+	if err != nil {
+		return "", err
+	}
+
+	token := strings.TrimSpace(string(data))
+	if token == "" {
+		return "", fmt.Errorf("token file %s is empty", s.path)
+	}
+
+	s.token = token
+	s.modTime = info.ModTime()
+	s.size = info.Size()
+
+	return token, nil
+}
+
+type cachedTokenSource struct {
+	source TokenSource
+	ttl    time.Duration
+
+	mu        sync.Mutex
+	token     string
+	expiresAt time.Time
+}
+
+// CacheToken remembers the token returned by source for ttl.
+func CacheToken(source TokenSource, ttl time.Duration) TokenSource {
+	return &cachedTokenSource{source: source, ttl: ttl}
+}
+
+func (s *cachedTokenSource) Token(ctx context.Context) (string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.token != "" && time.Now().Before(s.expiresAt) {
+		return s.token, nil
+	}
+
+	token, err := s.source.Token(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	s.token = token
+	s.expiresAt = time.Now().Add(s.ttl)
+
+	return token, nil
+}
+
+// WithTokenSource makes the client ask source for a token before each
+// attempt of each request, so long-running services can rotate tokens without
+// rebuilding clients.
+func WithTokenSource(source TokenSource) Option {
+	return func(t *Todoist) {
+		if source != nil {
+			t.tokenSource = source
+		}
+	}
+}
+
+type tokenSourceKey struct{}
+
+// ContextWithTokenSource makes requests sent with ctx use source instead of
+// the client's, so one client can act on behalf of different users per call.
+func ContextWithTokenSource(ctx context.Context, source TokenSource) context.Context {
+	return context.WithValue(ctx, tokenSourceKey{}, source)
+}
+
+// ContextWithToken is ContextWithTokenSource for a fixed token.
+func ContextWithToken(ctx context.Context, token string) context.Context {
+	return ContextWithTokenSource(ctx, StaticToken(token))
+}
+
+// token returns the token for a request sent with ctx.
+func (t Todoist) token(ctx context.Context) (string, error) {
+	source, ok := ctx.Value(tokenSourceKey{}).(TokenSource)
+	if !ok {
+		source = t.tokenSource
+	}
+
+	if source == nil {
+		return "", errors.New("no token source configured")
+	}
+
+	return source.Token(ctx)
+}
@@ -0,0 +1,124 @@
+package todoist
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// countingToken returns "token-1", "token-2", ... on each call.
+type countingToken struct {
+	calls atomic.Int32
+}
+
+func (s *countingToken) Token(context.Context) (string, error) {
+	return fmt.Sprintf("token-%d", s.calls.Add(1)), nil
+}
+
+func TestFileTokenReload(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "token")
+	write := func(token string, modTime time.Time) {
+		t.Helper()
+		if err := os.WriteFile(path, []byte(token+"\n"), 0o600); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.Chtimes(path, modTime, modTime); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	start := time.Now().Add(-time.Hour)
+	source := FileToken(path)
+
+	steps := []struct {
+		write   string
+		modTime time.Time
+		want    string
+	}{
+		{"first", start, "first"},
+		{"", time.Time{}, "first"},
+		{"other", start.Add(time.Minute), "other"},
+		{"longer-token", start.Add(time.Minute), "longer-token"},
+	}
+
+	for i, step := range steps {
+		if step.write != "" {
+			write(step.write, step.modTime)
+		}
+
+		token, err := source.Token(context.Background())
+		if err != nil {
+			t.Fatal(err)
+		}
+		if token != step.want {
+			t.Errorf("step %d: token = %q, want %q", i+1, token, step.want)
+		}
+	}
+
+	write("", start.Add(2*time.Minute))
+	if _, err := source.Token(context.Background()); err == nil {
+		t.Error("empty token file: want an error")
+	}
+}
+
+func TestCacheTokenExpiry(t *testing.T) {
+	source := &countingToken{}
+	cached := CacheToken(source, 50*time.Millisecond)
+
+	for i := 0; i < 3; i++ {
+		if token, _ := cached.Token(context.Background()); token != "token-1" {
+			t.Errorf("call %d: token = %q, want token-1", i+1, token)
+		}
+	}
+
+	time.Sleep(60 * time.Millisecond)
+
+	if token, _ := cached.Token(context.Background()); token != "token-2" {
+		t.Errorf("after expiry: token = %q, want token-2", token)
+	}
+	if calls := source.calls.Load(); calls != 2 {
+		t.Errorf("source called %d times, want 2", calls)
+	}
+}
+
+func TestContextWithToken(t *testing.T) {
+	server := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"id": "1"}`)
+	})
+
+	client := server.client(WithTokenSource(StaticToken("client-token")))
+
+	if _, err := client.GetTask("1"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := client.GetTaskWithContext(ContextWithToken(context.Background(), "user-token"), "1"); err != nil {
+		t.Fatal(err)
+	}
+
+	requests := server.Requests()
+	for i, want := range []string{"Bearer client-token", "Bearer user-token"} {
+		if got := requests[i].Header.Get("Authorization"); got != want {
+			t.Errorf("request %d Authorization = %q, want %q", i+1, got, want)
+		}
+	}
+}
+
+func TestTokenPerAttempt(t *testing.T) {
+	server := newTestServer(t, failFirst(2, http.StatusServiceUnavailable, nil))
+
+	client := server.client(WithTokenSource(&countingToken{}), WithRetryPolicy(fastRetries))
+	if _, err := client.GetTask("1"); err != nil {
+		t.Fatal(err)
+	}
+
+	for i, request := range server.Requests() {
+		if got, want := request.Header.Get("Authorization"), fmt.Sprintf("Bearer token-%d", i+1); got != want {
+			t.Errorf("attempt %d Authorization = %q, want %q", i+1, got, want)
+		}
+	}
+}
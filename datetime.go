@@ -0,0 +1,125 @@
+package todoist
+
+import (
+	"bytes"
+	"encoding/json"
+	"time"
+)
+
+const (
+	DATE_LAYOUT              = "2006-01-02"
+	FLOATING_DATETIME_LAYOUT = "2006-01-02T15:04:05.999999"
+)
+
+// Date is a calendar day without a time of day, as used by all-day due dates.
+// It is encoded as YYYY-MM-DD and decoded at midnight UTC.
+type Date struct {
+	time.Time
+}
+
+func NewDate(year int, month time.Month, day int) Date {
+	return Date{time.Date(year, month, day, 0, 0, 0, 0, time.UTC)}
+}
+
+func (d Date) String() string {
+	if d.IsZero() {
+		return ""
+	}
+	return d.Format(DATE_LAYOUT)
+}
+
+// Midnight returns the start of the day in loc.
+func (d Date) Midnight(loc *time.Location) time.Time {
+	return time.Date(d.Year(), d.Month(), d.Day(), 0, 0, 0, 0, loc)
+}
+
+func (d Date) MarshalJSON() ([]byte, error) {
+	if d.IsZero() {
+		return []byte("null"), nil
+	}
+	return json.Marshal(d.String())
+}
+
+func (d *Date) UnmarshalJSON(data []byte) error {
+	value, err := unquoteTime(data)
+	if err != nil || value == "" {
+		*d = Date{}
+		return err
+	}
+
+	parsed, err := time.Parse(DATE_LAYOUT, value)
+	if err != nil {
+		return err
+	}
+
+	*d = Date{parsed}
+	return nil
+}
+
+// DateTime is an RFC3339 timestamp. Todoist also sends "floating" due
+// datetimes without an offset, which are meant to happen at the same wall
+// clock time in any timezone; those are decoded in time.Local with Floating
+// set, and encoded back without an offset.
+type DateTime struct {
+	time.Time
+	Floating bool
+}
+
+func NewDateTime(t time.Time) DateTime {
+	return DateTime{Time: t}
+}
+
+func NewFloatingDateTime(year int, month time.Month, day, hour, min, sec int) DateTime {
+	return DateTime{Time: time.Date(year, month, day, hour, min, sec, 0, time.Local), Floating: true}
+}
+
+func (d DateTime) String() string {
+	if d.IsZero() {
+		return ""
+	}
+	if d.Floating {
+		return d.Format(FLOATING_DATETIME_LAYOUT)
+	}
+	return d.UTC().Format(time.RFC3339Nano)
+}
+
+func (d DateTime) MarshalJSON() ([]byte, error) {
+	if d.IsZero() {
+		return []byte("null"), nil
+	}
+	return json.Marshal(d.String())
+}
+
+func (d *DateTime) UnmarshalJSON(data []byte) error {
+	value, err := unquoteTime(data)
+	if err != nil || value == "" {
+		*d = DateTime{}
+		return err
+	}
+
+	if parsed, err := time.Parse(time.RFC3339Nano, value); err == nil {
+		*d = DateTime{Time: parsed}
+		return nil
+	}
+
+	parsed, err := time.ParseInLocation(FLOATING_DATETIME_LAYOUT, value, time.Local)
+	if err != nil {
+		return err
+	}
+
+	*d = DateTime{Time: parsed, Floating: true}
+	return nil
+}
+
+func unquoteTime(data []byte) (string, error) {
+	if bytes.Equal(data, []byte("null")) {
+		return "", nil
+	}
+
+	var value string
+	if err := json.Unmarshal(data, &value); err != nil {
+		return "", err
+	}
+
+	return value, nil
+}
@@ -0,0 +1,102 @@
+package todoist
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var (
+	ErrUnauthorized = errors.New("todoist: unauthorized")
+	ErrForbidden    = errors.New("todoist: forbidden")
+	ErrNotFound     = errors.New("todoist: not found")
+	ErrRateLimited  = errors.New("todoist: rate limited")
+)
+
+// APIError is returned whenever Todoist answers with an unexpected status
+// code. It matches the sentinel errors above through errors.Is.
+type APIError struct {
+	StatusCode int
+	Message    string
+	RequestID  string
+	Method     string
+	Endpoint   string
+	RetryAfter time.Duration
+}
+
+func (e *APIError) Error() string {
+	msg := fmt.Sprintf("HTTP request failed with status code: %d (%s %s", e.StatusCode, e.Method, e.Endpoint)
+	if e.RequestID != "" {
+		msg += ", request ID " + e.RequestID
+	}
+	msg += ")"
+
+	if e.Message != "" {
+		msg += ": " + e.Message
+	}
+
+	return msg
+}
+
+func (e *APIError) Is(target error) bool {
+	switch target {
+	case ErrUnauthorized:
+		return e.StatusCode == http.StatusUnauthorized
+	case ErrForbidden:
+		return e.StatusCode == http.StatusForbidden
+	case ErrNotFound:
+		return e.StatusCode == http.StatusNotFound
+	case ErrRateLimited:
+		return e.StatusCode == http.StatusTooManyRequests
+	}
+
+	return false
+}
+
+// maxErrorBodySize caps how much of an error response is kept as message.
+const maxErrorBodySize = 4 << 10
+
+func newAPIError(response *http.Response) error {
+	apiErr := &APIError{
+		StatusCode: response.StatusCode,
+		RetryAfter: parseRetryAfter(response.Header.Get("Retry-After")),
+	}
+
+	if request := response.Request; request != nil {
+		apiErr.Method = request.Method
+		apiErr.Endpoint = request.URL.Path
+		apiErr.RequestID = request.Header.Get("X-Request-Id")
+	}
+
+	data, err := io.ReadAll(io.LimitReader(response.Body, maxErrorBodySize))
+	if err == nil {
+		apiErr.Message = strings.TrimSpace(string(data))
+	}
+
+	return apiErr
+}
+
+func parseRetryAfter(value string) time.Duration {
+	if value == "" {
+		return 0
+	}
+
+	if seconds, err := strconv.Atoi(value); err == nil {
+		if seconds < 0 {
+			return 0
+		}
+		return time.Duration(seconds) * time.Second
+	}
+
+	if date, err := http.ParseTime(value); err == nil {
+		if wait := time.Until(date); wait > 0 {
+			return wait
+		}
+	}
+
+	return 0
+}
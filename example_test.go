@@ -0,0 +1,47 @@
+package todoist_test
+
+import (
+	"context"
+	"fmt"
+
+	todoist "github.com/felipeornelis/todoist-go-client"
+	"github.com/felipeornelis/todoist-go-client/todoisttest"
+)
+
+type span struct {
+	name string
+}
+
+type spanKey struct{}
+
+// printTracer stands for a tracing library: it starts a span when a call
+// starts, stores it in the context used for the call, and ends it with the
+// call's result.
+type printTracer struct{}
+
+func (printTracer) StartCall(ctx context.Context, call todoist.Call) (context.Context, func(todoist.CallResult)) {
+	s := &span{name: "todoist." + call.Operation}
+	fmt.Printf("start %s %s %s\n", s.name, call.Method, call.Route)
+
+	ctx = context.WithValue(ctx, spanKey{}, s)
+
+	return ctx, func(result todoist.CallResult) {
+		fmt.Printf("end %s status=%d attempts=%d err=%v\n", s.name, result.StatusCode, result.Attempts, result.Err)
+	}
+}
+
+func ExampleWithInstrumentation() {
+	server := todoisttest.NewServer()
+	defer server.Close()
+
+	client := server.Client(todoist.WithInstrumentation(printTracer{}))
+
+	task, _ := client.AddTask(todoist.AddTaskArgs{Content: "Buy milk"})
+	client.CloseTask(task.ID)
+
+	// Output:
+	// start todoist.AddTask POST /rest/v2/tasks
+	// end todoist.AddTask status=200 attempts=1 err=<nil>
+	// start todoist.CloseTask POST /rest/v2/tasks/{id}/close
+	// end todoist.CloseTask status=204 attempts=1 err=<nil>
+}
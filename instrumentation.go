@@ -0,0 +1,146 @@
+package todoist
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"time"
+)
+
+const (
+	RESOURCE_TASK    = "task"
+	RESOURCE_PROJECT = "project"
+	RESOURCE_SECTION = "section"
+	RESOURCE_LABEL   = "label"
+	RESOURCE_COMMENT = "comment"
+	RESOURCE_SYNC    = "sync"
+)
+
+// Call describes one API call, e.g. an AddTask, including all its attempts.
+type Call struct {
+	// Operation is the name of the client method, e.g. "AddTask".
+	Operation string
+	// Resource is one of the RESOURCE_* constants.
+	Resource string
+	Method   string
+	Endpoint string
+	// Route is Endpoint with resource IDs replaced by "{id}", e.g.
+	// "/rest/v2/tasks/{id}/close", so it suits metric labels and span names.
+	Route     string
+	RequestID string
+}
+
+// CallResult is the outcome of a Call.
+type CallResult struct {
+	Call
+	// StatusCode is the status of the last attempt, or 0 when it failed
+	// before a response was received.
+	StatusCode int
+	Attempts   int
+	Duration   time.Duration
+	// Err is set when no response was received. Calls answered with an
+	// error status only report it through StatusCode.
+	Err error
+}
+
+// Instrumentation is notified of every API call, so it can be traced and
+// measured with any tracing or metrics library. StartCall is called before
+// the first attempt; the context it returns is used for the call, e.g. to
+// carry a span, and the returned function is called once the call finished.
+type Instrumentation interface {
+	StartCall(ctx context.Context, call Call) (context.Context, func(CallResult))
+}
+
+// WithInstrumentation adds instrumentation to the client. Several can be
+// given, e.g. one for tracing and one for metrics.
+func WithInstrumentation(instrumentation ...Instrumentation) Option {
+	return func(t *Todoist) {
+		chain := make([]Instrumentation, 0, len(t.instrumentation)+len(instrumentation))
+		chain = append(chain, t.instrumentation...)
+		t.instrumentation = append(chain, instrumentation...)
+	}
+}
+
+type operationKey struct{}
+
+type operation struct {
+	name     string
+	resource string
+}
+
+// withOperation names the API calls made with ctx, unless an outer method,
+// e.g. MoveTasks committing a Batch, already named them.
+func withOperation(ctx context.Context, name, resource string) context.Context {
+	if _, ok := ctx.Value(operationKey{}).(operation); ok {
+		return ctx
+	}
+
+	return context.WithValue(ctx, operationKey{}, operation{name: name, resource: resource})
+}
+
+// startCall notifies the client's instrumentation of the call about to be
+// made with request. It returns the request to send and a function to call
+// with the outcome.
+func (t Todoist) startCall(request *http.Request) (*http.Request, func(response *http.Response, err error, attempts int)) {
+	if len(t.instrumentation) == 0 {
+		return request, func(*http.Response, error, int) {}
+	}
+
+	op, ok := request.Context().Value(operationKey{}).(operation)
+	if !ok {
+		op = operation{name: request.Method + " " + request.URL.Path}
+	}
+
+	call := Call{
+		Operation: op.name,
+		Resource:  op.resource,
+		Method:    request.Method,
+		Endpoint:  request.URL.Path,
+		Route:     route(request.URL.Path),
+		RequestID: request.Header.Get("X-Request-Id"),
+	}
+
+	ctx := request.Context()
+	ends := make([]func(CallResult), len(t.instrumentation))
+	for i, instrumentation := range t.instrumentation {
+		ctx, ends[i] = instrumentation.StartCall(ctx, call)
+	}
+
+	start := time.Now()
+
+	return request.WithContext(ctx), func(response *http.Response, err error, attempts int) {
+		result := CallResult{
+			Call:     call,
+			Attempts: attempts,
+			Duration: time.Since(start),
+			Err:      err,
+		}
+
+		if response != nil {
+			result.StatusCode = response.StatusCode
+		}
+
+		for i := len(ends) - 1; i >= 0; i-- {
+			ends[i](result)
+		}
+	}
+}
+
+// collections are the path segments followed by a resource ID, unless the
+// segment after them is one of the fixed endpoints below.
+var (
+	collections    = map[string]bool{"tasks": true, "projects": true, "sections": true, "labels": true, "comments": true}
+	fixedEndpoints = map[string]bool{"shared": true, "get_archived": true}
+)
+
+// route replaces the resource IDs of an endpoint path with "{id}".
+func route(path string) string {
+	segments := strings.Split(path, "/")
+	for i := 1; i < len(segments); i++ {
+		if collections[segments[i-1]] && segments[i] != "" && !fixedEndpoints[segments[i]] {
+			segments[i] = "{id}"
+		}
+	}
+
+	return strings.Join(segments, "/")
+}
@@ -0,0 +1,22 @@
+package todoist
+
+import "testing"
+
+func TestRoute(t *testing.T) {
+	tests := map[string]string{
+		"/rest/v2/tasks":                       "/rest/v2/tasks",
+		"/rest/v2/tasks/2995104339":            "/rest/v2/tasks/{id}",
+		"/rest/v2/tasks/2995104339/close":      "/rest/v2/tasks/{id}/close",
+		"/rest/v2/projects/6Jf8/collaborators": "/rest/v2/projects/{id}/collaborators",
+		"/rest/v2/labels/shared/rename":        "/rest/v2/labels/shared/rename",
+		"/sync/v9/projects/get_archived":       "/sync/v9/projects/get_archived",
+		"/sync/v9/sync":                        "/sync/v9/sync",
+		"/sync/v9/quick/add":                   "/sync/v9/quick/add",
+	}
+
+	for path, want := range tests {
+		if got := route(path); got != want {
+			t.Errorf("route(%q) = %q, want %q", path, got, want)
+		}
+	}
+}
@@ -2,45 +2,47 @@ package todoist
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
 	"io"
 	"net/http"
-
-	"github.com/felipeornelis/todoist-go-client/pkg"
 )
 
-const LABEL_URL = BASE_URL + "/labels"
+const (
+	LABEL_PATH = "/labels"
+	LABEL_URL  = BASE_URL + LABEL_PATH
+)
 
 type Label struct {
 	ID         string `json:"id"`
 	Name       string `json:"name"`
 	Color      string `json:"color"`
-	Order      uint8  `json:"order"`
+	Order      int    `json:"order"`
 	IsFavorite bool   `json:"is_favorite"`
 }
 
 func (t Todoist) GetPersonalLabels() ([]Label, error) {
-	request, err := http.NewRequest(http.MethodGet, LABEL_URL, nil)
-	if err != nil {
-		return nil, err
-	}
+	return t.GetPersonalLabelsWithContext(context.Background())
+}
 
-	request.Header.Set("Authorization", fmt.Sprintf("Bearer %s", t.authToken))
+func (t Todoist) GetPersonalLabelsWithContext(ctx context.Context) ([]Label, error) {
+	ctx = withOperation(ctx, "GetPersonalLabels", RESOURCE_LABEL)
 
-	client := &http.Client{
-		Timeout: MAX_TIMEOUT,
+	request, err := t.newRequest(ctx, http.MethodGet, LABEL_PATH, nil)
+	if err != nil {
+		return nil, err
 	}
 
-	response, err := client.Do(request)
+	response, err := t.do(request)
 	if err != nil {
 		return nil, err
 	}
 	defer response.Body.Close()
 
 	if response.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("HTTP request failed with status code: %d", response.StatusCode)
+		return nil, newAPIError(response)
 	}
 
 	data, err := io.ReadAll(response.Body)
@@ -57,31 +59,31 @@ func (t Todoist) GetPersonalLabels() ([]Label, error) {
 }
 
 func (t Todoist) GetPersonalLabel(id string) (Label, error) {
+	return t.GetPersonalLabelWithContext(context.Background(), id)
+}
+
+func (t Todoist) GetPersonalLabelWithContext(ctx context.Context, id string) (Label, error) {
+	ctx = withOperation(ctx, "GetPersonalLabel", RESOURCE_LABEL)
+
 	if id == "" {
 		return Label{}, errors.New("ID is required")
 	}
 
-	url := fmt.Sprintf("%s/%s", LABEL_URL, id)
+	path := fmt.Sprintf("%s/%s", LABEL_PATH, id)
 
-	request, err := http.NewRequest(http.MethodGet, url, nil)
+	request, err := t.newRequest(ctx, http.MethodGet, path, nil)
 	if err != nil {
 		return Label{}, err
 	}
 
-	request.Header.Set("Authorization", fmt.Sprintf("Bearer %s", t.authToken))
-
-	client := &http.Client{
-		Timeout: MAX_TIMEOUT,
-	}
-
-	response, err := client.Do(request)
+	response, err := t.do(request)
 	if err != nil {
 		return Label{}, err
 	}
 	defer response.Body.Close()
 
 	if response.StatusCode != http.StatusOK {
-		return Label{}, fmt.Errorf("HTTP request failed with status code: %d", response.StatusCode)
+		return Label{}, newAPIError(response)
 	}
 
 	data, err := io.ReadAll(response.Body)
@@ -99,12 +101,18 @@ func (t Todoist) GetPersonalLabel(id string) (Label, error) {
 
 type AddPersonalLabelArgs struct {
 	Name       string `json:"name"`
-	Order      uint8  `json:"order,omitempty"`
+	Order      int    `json:"order,omitempty"`
 	Color      string `json:"color,omitempty"`
 	IsFavorite bool   `json:"is_favorite,omitempty"`
 }
 
 func (t Todoist) AddPersonalLabel(args AddPersonalLabelArgs) (Label, error) {
+	return t.AddPersonalLabelWithContext(context.Background(), args)
+}
+
+func (t Todoist) AddPersonalLabelWithContext(ctx context.Context, args AddPersonalLabelArgs) (Label, error) {
+	ctx = withOperation(ctx, "AddPersonalLabel", RESOURCE_LABEL)
+
 	if args.Name == "" {
 		return Label{}, errors.New("`name` is required")
 	}
@@ -114,27 +122,21 @@ func (t Todoist) AddPersonalLabel(args AddPersonalLabelArgs) (Label, error) {
 		return Label{}, err
 	}
 
-	request, err := http.NewRequest(http.MethodPost, LABEL_URL, bytes.NewReader(bodyRequest))
+	request, err := t.newRequest(ctx, http.MethodPost, LABEL_PATH, bytes.NewReader(bodyRequest))
 	if err != nil {
 		return Label{}, err
 	}
 
 	request.Header.Set("Content-Type", "application/json")
-	request.Header.Set("X-Request-Id", pkg.NewUUID())
-	request.Header.Set("Authorization", fmt.Sprintf("Bearer %s", t.authToken))
 
-	client := &http.Client{
-		Timeout: MAX_TIMEOUT,
-	}
-
-	response, err := client.Do(request)
+	response, err := t.do(request)
 	if err != nil {
 		return Label{}, err
 	}
 	defer response.Body.Close()
 
 	if response.StatusCode != http.StatusOK {
-		return Label{}, fmt.Errorf("HTTP request failed with status code: %d", response.StatusCode)
+		return Label{}, newAPIError(response)
 	}
 
 	data, err := io.ReadAll(response.Body)
@@ -152,12 +154,18 @@ func (t Todoist) AddPersonalLabel(args AddPersonalLabelArgs) (Label, error) {
 
 type UpdatePersonalLabelArgs struct {
 	Name       string `json:"name,omitempty"`
-	Order      uint8  `json:"order,omitempty"`
+	Order      int    `json:"order,omitempty"`
 	Color      string `json:"color,omitempty"`
 	IsFavorite bool   `json:"is_favorite,omitempty"`
 }
 
 func (t Todoist) UpdatePersonalLabel(id string, args UpdatePersonalLabelArgs) (Label, error) {
+	return t.UpdatePersonalLabelWithContext(context.Background(), id, args)
+}
+
+func (t Todoist) UpdatePersonalLabelWithContext(ctx context.Context, id string, args UpdatePersonalLabelArgs) (Label, error) {
+	ctx = withOperation(ctx, "UpdatePersonalLabel", RESOURCE_LABEL)
+
 	if id == "" {
 		return Label{}, errors.New("ID is required")
 	}
@@ -167,29 +175,23 @@ func (t Todoist) UpdatePersonalLabel(id string, args UpdatePersonalLabelArgs) (L
 		return Label{}, err
 	}
 
-	url := fmt.Sprintf("%s/%s", LABEL_URL, id)
+	path := fmt.Sprintf("%s/%s", LABEL_PATH, id)
 
-	request, err := http.NewRequest(http.MethodPost, url, bytes.NewReader(bodyRequest))
+	request, err := t.newRequest(ctx, http.MethodPost, path, bytes.NewReader(bodyRequest))
 	if err != nil {
 		return Label{}, err
 	}
 
 	request.Header.Set("Content-Type", "application/json")
-	request.Header.Set("X-Request-Id", pkg.NewUUID())
-	request.Header.Set("Authorization", fmt.Sprintf("Bearer %s", t.authToken))
-
-	client := &http.Client{
-		Timeout: MAX_TIMEOUT,
-	}
 
-	response, err := client.Do(request)
+	response, err := t.do(request)
 	if err != nil {
 		return Label{}, err
 	}
 	defer response.Body.Close()
 
 	if response.StatusCode != http.StatusOK {
-		return Label{}, fmt.Errorf("HTTP request failed with status code: %d", response.StatusCode)
+		return Label{}, newAPIError(response)
 	}
 
 	data, err := io.ReadAll(response.Body)
@@ -206,31 +208,31 @@ func (t Todoist) UpdatePersonalLabel(id string, args UpdatePersonalLabelArgs) (L
 }
 
 func (t Todoist) DeleteLabel(id string) error {
+	return t.DeleteLabelWithContext(context.Background(), id)
+}
+
+func (t Todoist) DeleteLabelWithContext(ctx context.Context, id string) error {
+	ctx = withOperation(ctx, "DeleteLabel", RESOURCE_LABEL)
+
 	if id == "" {
 		return errors.New("ID is required")
 	}
 
-	url := fmt.Sprintf("%s/%s", LABEL_URL, id)
-
-	client := &http.Client{
-		Timeout: MAX_TIMEOUT,
-	}
+	path := fmt.Sprintf("%s/%s", LABEL_PATH, id)
 
-	request, err := http.NewRequest(http.MethodDelete, url, nil)
+	request, err := t.newRequest(ctx, http.MethodDelete, path, nil)
 	if err != nil {
 		return err
 	}
 
-	request.Header.Set("Authorization", fmt.Sprintf("Bearer %s", t.authToken))
-
-	response, err := client.Do(request)
+	response, err := t.do(request)
 	if err != nil {
 		return err
 	}
 	defer response.Body.Close()
 
 	if response.StatusCode != http.StatusNoContent {
-		return fmt.Errorf("HTTP request failed with status code: %d", response.StatusCode)
+		return newAPIError(response)
 	}
 
 	return nil
@@ -241,33 +243,30 @@ type GetSharedLabelsArgs struct {
 }
 
 func (t Todoist) GetSharedLabels(args GetSharedLabelsArgs) ([]string, error) {
-	bodyRequest, err := json.Marshal(args)
-	if err != nil {
-		return nil, err
-	}
+	return t.GetSharedLabelsWithContext(context.Background(), args)
+}
 
-	url := fmt.Sprintf("%s/shared", LABEL_URL)
+func (t Todoist) GetSharedLabelsWithContext(ctx context.Context, args GetSharedLabelsArgs) ([]string, error) {
+	ctx = withOperation(ctx, "GetSharedLabels", RESOURCE_LABEL)
 
-	request, err := http.NewRequest(http.MethodGet, url, bytes.NewReader(bodyRequest))
-	if err != nil {
-		return nil, err
+	path := fmt.Sprintf("%s/shared", LABEL_PATH)
+	if args.OmitPersonal {
+		path += "?omit_personal=true"
 	}
 
-	request.Header.Set("Authorization", fmt.Sprintf("Bearer %s", t.authToken))
-	request.Header.Set("Content-Type", "application/json")
-
-	client := &http.Client{
-		Timeout: MAX_TIMEOUT,
+	request, err := t.newRequest(ctx, http.MethodGet, path, nil)
+	if err != nil {
+		return nil, err
 	}
 
-	response, err := client.Do(request)
+	response, err := t.do(request)
 	if err != nil {
 		return nil, err
 	}
 	defer response.Body.Close()
 
 	if response.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("HTTP request failed with status code: %d", response.StatusCode)
+		return nil, newAPIError(response)
 	}
 
 	data, err := io.ReadAll(response.Body)
@@ -289,6 +288,12 @@ type RenameSharedLabelsArgs struct {
 }
 
 func (t Todoist) RenameSharedLabels(args RenameSharedLabelsArgs) error {
+	return t.RenameSharedLabelsWithContext(context.Background(), args)
+}
+
+func (t Todoist) RenameSharedLabelsWithContext(ctx context.Context, args RenameSharedLabelsArgs) error {
+	ctx = withOperation(ctx, "RenameSharedLabels", RESOURCE_LABEL)
+
 	if args.Name == "" || args.NewName == "" {
 		return errors.New("`name` and `new_name` are required")
 	}
@@ -303,29 +308,23 @@ func (t Todoist) RenameSharedLabels(args RenameSharedLabelsArgs) error {
 		return err
 	}
 
-	url := fmt.Sprintf("%s/shared/rename", LABEL_URL)
+	path := fmt.Sprintf("%s/shared/rename", LABEL_PATH)
 
-	request, err := http.NewRequest(http.MethodPost, url, bytes.NewReader(bodyRequest))
+	request, err := t.newRequest(ctx, http.MethodPost, path, bytes.NewReader(bodyRequest))
 	if err != nil {
 		return err
 	}
 
-	request.Header.Set("Authorization", fmt.Sprintf("Bearer %s", t.authToken))
-	request.Header.Set("X-Request-Id", pkg.NewUUID())
 	request.Header.Set("Content-Type", "application/json")
 
-	client := &http.Client{
-		Timeout: MAX_TIMEOUT,
-	}
-
-	response, err := client.Do(request)
+	response, err := t.do(request)
 	if err != nil {
 		return err
 	}
 	defer response.Body.Close()
 
 	if response.StatusCode != http.StatusNoContent {
-		return fmt.Errorf("HTTP request failed with status code: %d", response.StatusCode)
+		return newAPIError(response)
 	}
 
 	return nil
@@ -336,6 +335,12 @@ type RemoveSharedLabelsArgs struct {
 }
 
 func (t Todoist) RemoveSharedLabels(args RemoveSharedLabelsArgs) error {
+	return t.RemoveSharedLabelsWithContext(context.Background(), args)
+}
+
+func (t Todoist) RemoveSharedLabelsWithContext(ctx context.Context, args RemoveSharedLabelsArgs) error {
+	ctx = withOperation(ctx, "RemoveSharedLabels", RESOURCE_LABEL)
+
 	if args.Name == "" {
 		return errors.New("`name` is required")
 	}
@@ -345,29 +350,23 @@ func (t Todoist) RemoveSharedLabels(args RemoveSharedLabelsArgs) error {
 		return err
 	}
 
-	url := fmt.Sprintf("%s/shared/remove", LABEL_URL)
+	path := fmt.Sprintf("%s/shared/remove", LABEL_PATH)
 
-	request, err := http.NewRequest(http.MethodPost, url, bytes.NewReader(bodyRequest))
+	request, err := t.newRequest(ctx, http.MethodPost, path, bytes.NewReader(bodyRequest))
 	if err != nil {
 		return err
 	}
 
-	request.Header.Set("Authorization", fmt.Sprintf("Bearer %s", t.authToken))
-	request.Header.Set("X-Request-Id", pkg.NewUUID())
 	request.Header.Set("Content-Type", "application/json")
 
-	client := &http.Client{
-		Timeout: MAX_TIMEOUT,
-	}
-
-	response, err := client.Do(request)
+	response, err := t.do(request)
 	if err != nil {
 		return err
 	}
 	defer response.Body.Close()
 
 	if response.StatusCode != http.StatusNoContent {
-		return fmt.Errorf("HTTP request failed with status code: %d", response.StatusCode)
+		return newAPIError(response)
 	}
 
 	return nil
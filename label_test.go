@@ -0,0 +1,46 @@
+package todoist
+
+import (
+	"fmt"
+	"net/http"
+	"reflect"
+	"testing"
+)
+
+func TestGetSharedLabels(t *testing.T) {
+	tests := []struct {
+		name      string
+		args      GetSharedLabelsArgs
+		wantQuery string
+	}{
+		{"all", GetSharedLabelsArgs{}, ""},
+		{"omit personal", GetSharedLabelsArgs{OmitPersonal: true}, "omit_personal=true"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+				fmt.Fprint(w, `["shared"]`)
+			})
+
+			labels, err := server.client().GetSharedLabels(tt.args)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if want := []string{"shared"}; !reflect.DeepEqual(labels, want) {
+				t.Errorf("labels = %v, want %v", labels, want)
+			}
+
+			request := server.Requests()[0]
+			if request.Method != http.MethodGet || request.Path != LABEL_PATH+"/shared" {
+				t.Errorf("request = %s %s, want GET %s/shared", request.Method, request.Path, LABEL_PATH)
+			}
+			if got := request.Query.Encode(); got != tt.wantQuery {
+				t.Errorf("query = %q, want %q", got, tt.wantQuery)
+			}
+			if request.Body != "" || request.Header.Get("Content-Type") != "" {
+				t.Errorf("GET sent a body %q with Content-Type %q", request.Body, request.Header.Get("Content-Type"))
+			}
+		})
+	}
+}
@@ -0,0 +1,179 @@
+package todoist
+
+import (
+	"encoding/json"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+const REDACTED = "REDACTED"
+
+// LogOptions controls what a client given WithLogger records besides the
+// method, endpoint, status, latency, request ID and attempt of each request.
+type LogOptions struct {
+	// Headers adds the request headers. The Authorization header is always
+	// redacted.
+	Headers bool
+	// Bodies adds the request bodies.
+	Bodies bool
+	// RedactContent replaces the content, description and quick add text and
+	// note of tasks and comments in logged bodies with REDACTED.
+	RedactContent bool
+}
+
+// redactedFields are the body fields removed when LogOptions.RedactContent
+// is set.
+var redactedFields = map[string]bool{
+	"content":     true,
+	"description": true,
+	"text":        true,
+	"note":        true,
+}
+
+// WithLogger makes the client log every attempt of every request to logger.
+// Successful requests are logged at slog.LevelInfo, attempts about to be
+// retried at slog.LevelWarn, and failed requests at slog.LevelWarn for 4xx
+// statuses and slog.LevelError otherwise.
+func WithLogger(logger *slog.Logger) Option {
+	return func(t *Todoist) {
+		t.logger = logger
+	}
+}
+
+// WithLogOptions sets what WithLogger records.
+func WithLogOptions(options LogOptions) Option {
+	return func(t *Todoist) {
+		t.logOptions = options
+	}
+}
+
+// logAttempt records one attempt of request. willRetry tells whether do is
+// going to try again.
+func (t Todoist) logAttempt(request *http.Request, response *http.Response, err error, attempt int, latency time.Duration, willRetry bool) {
+	if t.logger == nil {
+		return
+	}
+
+	attrs := []slog.Attr{
+		slog.String("method", request.Method),
+		slog.String("endpoint", request.URL.Path),
+		slog.String("request_id", request.Header.Get("X-Request-Id")),
+		slog.Int("attempt", attempt),
+		slog.Duration("latency", latency),
+	}
+
+	level := slog.LevelInfo
+
+	switch {
+	case err != nil:
+		attrs = append(attrs, slog.String("error", err.Error()))
+		level = slog.LevelError
+	case response.StatusCode >= http.StatusBadRequest:
+		attrs = append(attrs, slog.Int("status", response.StatusCode))
+		level = slog.LevelError
+		if response.StatusCode < http.StatusInternalServerError {
+			level = slog.LevelWarn
+		}
+	default:
+		attrs = append(attrs, slog.Int("status", response.StatusCode))
+	}
+
+	if willRetry {
+		attrs = append(attrs, slog.Bool("retrying", true))
+		level = slog.LevelWarn
+	}
+
+	if t.logOptions.Headers {
+		attrs = append(attrs, slog.Any("headers", redactHeaders(request.Header)))
+	}
+
+	if t.logOptions.Bodies && request.GetBody != nil {
+		if body, err := request.GetBody(); err == nil {
+			data, _ := io.ReadAll(body)
+			body.Close()
+			attrs = append(attrs, slog.String("body", t.redactBody(request.Header.Get("Content-Type"), data)))
+		}
+	}
+
+	t.logger.LogAttrs(request.Context(), level, "todoist request", attrs...)
+}
+
+func redactHeaders(header http.Header) map[string]string {
+	headers := make(map[string]string, len(header))
+
+	for key, values := range header {
+		value := strings.Join(values, ", ")
+		if key == "Authorization" {
+			value = REDACTED
+		}
+		headers[key] = value
+	}
+
+	return headers
+}
+
+func (t Todoist) redactBody(contentType string, data []byte) string {
+	if !t.logOptions.RedactContent || len(data) == 0 {
+		return string(data)
+	}
+
+	if strings.HasPrefix(contentType, "application/x-www-form-urlencoded") {
+		form, err := url.ParseQuery(string(data))
+		if err != nil {
+			return REDACTED
+		}
+
+		for key, values := range form {
+			for i, value := range values {
+				if redactedFields[key] {
+					values[i] = REDACTED
+				} else if strings.HasPrefix(value, "[") || strings.HasPrefix(value, "{") {
+					values[i] = redactJSON([]byte(value))
+				}
+			}
+		}
+
+		return form.Encode()
+	}
+
+	return redactJSON(data)
+}
+
+// redactJSON replaces the redacted fields found at any depth of data. Bodies
+// that are not JSON are redacted whole.
+func redactJSON(data []byte) string {
+	var value any
+	if err := json.Unmarshal(data, &value); err != nil {
+		return REDACTED
+	}
+
+	redacted, err := json.Marshal(redactValue(value))
+	if err != nil {
+		return REDACTED
+	}
+
+	return string(redacted)
+}
+
+func redactValue(value any) any {
+	switch value := value.(type) {
+	case map[string]any:
+		for key, field := range value {
+			if redactedFields[key] {
+				value[key] = REDACTED
+			} else {
+				value[key] = redactValue(field)
+			}
+		}
+	case []any:
+		for i, item := range value {
+			value[i] = redactValue(item)
+		}
+	}
+
+	return value
+}
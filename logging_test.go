@@ -0,0 +1,136 @@
+package todoist
+
+import (
+	"bytes"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+// logRecords decodes the JSON records written to buf.
+func logRecords(t *testing.T, buf *bytes.Buffer) []map[string]any {
+	t.Helper()
+
+	var records []map[string]any
+	for _, line := range strings.Split(strings.TrimSpace(buf.String()), "\n") {
+		var record map[string]any
+		if err := json.Unmarshal([]byte(line), &record); err != nil {
+			t.Fatalf("invalid record %q: %v", line, err)
+		}
+		records = append(records, record)
+	}
+
+	return records
+}
+
+func newLoggedClient(server *testServer, buf *bytes.Buffer, options LogOptions, opts ...Option) Todoist {
+	logger := slog.New(slog.NewJSONHandler(buf, &slog.HandlerOptions{Level: slog.LevelDebug}))
+	return server.client(append([]Option{WithLogger(logger), WithLogOptions(options)}, opts...)...)
+}
+
+func TestLogHeadersRedactToken(t *testing.T) {
+	server := newTestServer(t, nil)
+
+	var buf bytes.Buffer
+	client := newLoggedClient(server, &buf, LogOptions{Headers: true}, WithTokenSource(StaticToken("secret-token")))
+
+	if _, err := client.GetTask("1"); err != nil {
+		t.Fatal(err)
+	}
+
+	if strings.Contains(buf.String(), "secret-token") {
+		t.Fatalf("token logged: %s", buf.String())
+	}
+
+	headers, _ := logRecords(t, &buf)[0]["headers"].(map[string]any)
+	if headers["Authorization"] != REDACTED || headers["X-Request-Id"] == "" {
+		t.Errorf("headers = %v", headers)
+	}
+}
+
+func TestLogRedactContent(t *testing.T) {
+	tests := []struct {
+		name string
+		call func(Todoist) error
+		// kept must be logged, secret must not.
+		kept, secret string
+	}{
+		{
+			"json",
+			func(client Todoist) error {
+				_, err := client.AddTask(AddTaskArgs{Content: "Call the doctor", Description: "About the results", Priority: 4})
+				return err
+			},
+			`"priority":4`, "doctor",
+		},
+		{
+			"form",
+			func(client Todoist) error {
+				_, err := client.QuickAddTask(QuickAddTaskArgs{Text: "Call the doctor tomorrow", AutoReminder: true})
+				return err
+			},
+			"auto_reminder=true", "doctor",
+		},
+		{
+			"sync commands",
+			func(client Todoist) error {
+				batch := client.NewBatch()
+				batch.AddTask(AddTaskArgs{Content: "Call the doctor", ProjectID: "2203306141"})
+				batch.UpdateTask("2995104339", UpdateTaskArgs{Description: "About the results"})
+				_, err := batch.Commit()
+				return err
+			},
+			"2203306141", "doctor",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := newTestServer(t, nil)
+
+			var buf bytes.Buffer
+			client := newLoggedClient(server, &buf, LogOptions{Bodies: true, RedactContent: true})
+
+			if err := tt.call(client); err != nil {
+				t.Fatal(err)
+			}
+
+			body, _ := logRecords(t, &buf)[0]["body"].(string)
+			if strings.Contains(body, tt.secret) || strings.Contains(body, "results") {
+				t.Errorf("body not redacted: %s", body)
+			}
+			if !strings.Contains(body, REDACTED) || !strings.Contains(body, tt.kept) {
+				t.Errorf("body = %s, want %s kept and content redacted", body, tt.kept)
+			}
+		})
+	}
+}
+
+func TestLogRetries(t *testing.T) {
+	server := newTestServer(t, failFirst(1, http.StatusServiceUnavailable, nil))
+
+	var buf bytes.Buffer
+	client := newLoggedClient(server, &buf, LogOptions{}, WithRetryPolicy(fastRetries))
+
+	if _, err := client.GetTask("1"); err != nil {
+		t.Fatal(err)
+	}
+
+	records := logRecords(t, &buf)
+	if len(records) != 2 {
+		t.Fatalf("got %d records, want 2", len(records))
+	}
+
+	retried, succeeded := records[0], records[1]
+	if retried["level"] != "WARN" || retried["retrying"] != true || retried["status"] != float64(http.StatusServiceUnavailable) || retried["attempt"] != float64(1) {
+		t.Errorf("retried attempt logged as %v", retried)
+	}
+	if succeeded["level"] != "INFO" || succeeded["retrying"] != nil || succeeded["attempt"] != float64(2) {
+		t.Errorf("last attempt logged as %v", succeeded)
+	}
+	if retried["request_id"] != succeeded["request_id"] {
+		t.Errorf("attempts logged with request IDs %v and %v", retried["request_id"], succeeded["request_id"])
+	}
+}
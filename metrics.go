@@ -0,0 +1,152 @@
+package todoist
+
+import (
+	"context"
+	"net/http"
+	"sort"
+	"sync"
+	"time"
+)
+
+// DEFAULT_LATENCY_BUCKETS are the upper bounds of the latency histograms kept
+// by a Metrics created without buckets.
+var DEFAULT_LATENCY_BUCKETS = []time.Duration{
+	50 * time.Millisecond,
+	100 * time.Millisecond,
+	250 * time.Millisecond,
+	500 * time.Millisecond,
+	time.Second,
+	2500 * time.Millisecond,
+	5 * time.Second,
+	10 * time.Second,
+}
+
+// Metrics is an in-memory Instrumentation counting calls and errors and
+// keeping a latency histogram per operation and endpoint. It is safe for
+// concurrent use, and suits tests as well as periodic export to a metrics
+// backend.
+type Metrics struct {
+	buckets []time.Duration
+
+	mu         sync.Mutex
+	operations map[metricsKey]*OperationMetrics
+}
+
+type metricsKey struct {
+	operation string
+	method    string
+	endpoint  string
+}
+
+// OperationMetrics are the metrics of one operation on one endpoint, e.g.
+// "AddTask" on "POST /rest/v2/tasks". Operations calling several endpoints
+// have metrics for each of them.
+type OperationMetrics struct {
+	Operation string
+	Resource  string
+	Method    string
+	// Endpoint is the route of the call, with resource IDs replaced by
+	// "{id}".
+	Endpoint string
+	Calls    int
+	// Errors counts calls that failed, or ended with a status other than 2xx.
+	Errors   int
+	Statuses map[int]int
+	// Latency counts calls per bucket: Latency[i] counts calls that took at
+	// most Buckets[i], and the last element those slower than every bucket.
+	Buckets      []time.Duration
+	Latency      []int
+	TotalLatency time.Duration
+}
+
+// NewMetrics returns empty Metrics using the given latency buckets, or
+// DEFAULT_LATENCY_BUCKETS if none are given.
+func NewMetrics(buckets ...time.Duration) *Metrics {
+	if len(buckets) == 0 {
+		buckets = DEFAULT_LATENCY_BUCKETS
+	}
+
+	sorted := append([]time.Duration(nil), buckets...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+
+	return &Metrics{
+		buckets:    sorted,
+		operations: make(map[metricsKey]*OperationMetrics),
+	}
+}
+
+func (m *Metrics) StartCall(ctx context.Context, call Call) (context.Context, func(CallResult)) {
+	return ctx, m.record
+}
+
+func (m *Metrics) record(result CallResult) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	key := metricsKey{operation: result.Operation, method: result.Method, endpoint: result.Route}
+
+	metrics, ok := m.operations[key]
+	if !ok {
+		metrics = &OperationMetrics{
+			Operation: result.Operation,
+			Resource:  result.Resource,
+			Method:    result.Method,
+			Endpoint:  result.Route,
+			Statuses:  make(map[int]int),
+			Buckets:   m.buckets,
+			Latency:   make([]int, len(m.buckets)+1),
+		}
+		m.operations[key] = metrics
+	}
+
+	metrics.Calls++
+	if result.Err != nil || result.StatusCode < http.StatusOK || result.StatusCode >= http.StatusMultipleChoices {
+		metrics.Errors++
+	}
+	if result.StatusCode != 0 {
+		metrics.Statuses[result.StatusCode]++
+	}
+
+	bucket := sort.Search(len(m.buckets), func(i int) bool { return result.Duration <= m.buckets[i] })
+	metrics.Latency[bucket]++
+	metrics.TotalLatency += result.Duration
+}
+
+// Snapshot returns a copy of the metrics of every operation and endpoint
+// called so far, sorted by operation, then endpoint and method.
+func (m *Metrics) Snapshot() []OperationMetrics {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	snapshot := make([]OperationMetrics, 0, len(m.operations))
+	for _, metrics := range m.operations {
+		copied := *metrics
+		copied.Statuses = make(map[int]int, len(metrics.Statuses))
+		for status, count := range metrics.Statuses {
+			copied.Statuses[status] = count
+		}
+		copied.Latency = append([]int(nil), metrics.Latency...)
+		snapshot = append(snapshot, copied)
+	}
+
+	sort.Slice(snapshot, func(i, j int) bool {
+		a, b := snapshot[i], snapshot[j]
+		if a.Operation != b.Operation {
+			return a.Operation < b.Operation
+		}
+		if a.Endpoint != b.Endpoint {
+			return a.Endpoint < b.Endpoint
+		}
+		return a.Method < b.Method
+	})
+
+	return snapshot
+}
+
+// Reset forgets every recorded call.
+func (m *Metrics) Reset() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.operations = make(map[metricsKey]*OperationMetrics)
+}
@@ -0,0 +1,50 @@
+package todoist
+
+import "net/http"
+
+// RoundTripFunc adapts a function to an http.RoundTripper.
+type RoundTripFunc func(*http.Request) (*http.Response, error)
+
+func (f RoundTripFunc) RoundTrip(request *http.Request) (*http.Response, error) {
+	return f(request)
+}
+
+// Middleware wraps the RoundTripper that sends requests, e.g. to log, audit,
+// inject headers or fail requests on purpose. It runs once per attempt, after
+// the rate limiter and inside the retry loop, and sees every request sent by
+// the client. As with any RoundTripper, a middleware changing the request
+// should work on a clone of it.
+type Middleware func(next http.RoundTripper) http.RoundTripper
+
+// WithMiddleware appends middleware to the client's chain. The first one
+// given is the outermost, so it sees the request first and the response last.
+func WithMiddleware(middleware ...Middleware) Option {
+	return func(t *Todoist) {
+		chain := make([]Middleware, 0, len(t.middleware)+len(middleware))
+		chain = append(chain, t.middleware...)
+		t.middleware = append(chain, middleware...)
+	}
+}
+
+// HeaderMiddleware sets the given header on every request.
+func HeaderMiddleware(key, value string) Middleware {
+	return func(next http.RoundTripper) http.RoundTripper {
+		return RoundTripFunc(func(request *http.Request) (*http.Response, error) {
+			request = request.Clone(request.Context())
+			request.Header.Set(key, value)
+
+			return next.RoundTrip(request)
+		})
+	}
+}
+
+// roundTripper returns the client's HTTP client wrapped in its middleware.
+func (t Todoist) roundTripper() http.RoundTripper {
+	var next http.RoundTripper = RoundTripFunc(t.httpClient.Do)
+
+	for i := len(t.middleware) - 1; i >= 0; i-- {
+		next = t.middleware[i](next)
+	}
+
+	return next
+}
@@ -0,0 +1,89 @@
+package todoist
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"sync"
+	"testing"
+)
+
+// tracingMiddleware appends name to trace when a request goes in and
+// "/"+name when its response comes out.
+func tracingMiddleware(mu *sync.Mutex, trace *[]string, name string) Middleware {
+	return func(next http.RoundTripper) http.RoundTripper {
+		return RoundTripFunc(func(request *http.Request) (*http.Response, error) {
+			mu.Lock()
+			*trace = append(*trace, name)
+			mu.Unlock()
+
+			response, err := next.RoundTrip(request)
+
+			mu.Lock()
+			*trace = append(*trace, "/"+name)
+			mu.Unlock()
+
+			return response, err
+		})
+	}
+}
+
+func TestMiddlewareOrder(t *testing.T) {
+	server := newTestServer(t, nil)
+
+	var mu sync.Mutex
+	var trace []string
+	client := server.client(
+		WithMiddleware(tracingMiddleware(&mu, &trace, "outer"), tracingMiddleware(&mu, &trace, "middle")),
+		WithMiddleware(tracingMiddleware(&mu, &trace, "inner")),
+	)
+
+	if _, err := client.GetTask("1"); err != nil {
+		t.Fatal(err)
+	}
+
+	if want := []string{"outer", "middle", "inner", "/inner", "/middle", "/outer"}; !reflect.DeepEqual(trace, want) {
+		t.Errorf("trace = %v, want %v", trace, want)
+	}
+}
+
+func TestMiddlewarePerAttempt(t *testing.T) {
+	server := newTestServer(t, failFirst(2, http.StatusServiceUnavailable, nil))
+
+	var mu sync.Mutex
+	var trace []string
+	client := server.client(WithRetryPolicy(fastRetries), WithMiddleware(tracingMiddleware(&mu, &trace, "m")))
+
+	if _, err := client.GetTask("1"); err != nil {
+		t.Fatal(err)
+	}
+
+	if want := []string{"m", "/m", "m", "/m", "m", "/m"}; !reflect.DeepEqual(trace, want) {
+		t.Errorf("trace = %v, want one pass per attempt %v", trace, want)
+	}
+}
+
+func TestHeaderMiddleware(t *testing.T) {
+	var sent *http.Request
+	next := RoundTripFunc(func(request *http.Request) (*http.Response, error) {
+		sent = request
+		return httptest.NewRecorder().Result(), nil
+	})
+
+	request := httptest.NewRequest(http.MethodGet, "https://api.todoist.com/rest/v2/tasks", nil)
+	request.Header.Set("X-Request-Id", "1")
+
+	if _, err := HeaderMiddleware("X-Tenant", "acme")(next).RoundTrip(request); err != nil {
+		t.Fatal(err)
+	}
+
+	if got := sent.Header.Get("X-Tenant"); got != "acme" {
+		t.Errorf("sent X-Tenant = %q, want acme", got)
+	}
+	if sent.Header.Get("X-Request-Id") != "1" {
+		t.Error("sent request lost the caller's headers")
+	}
+	if got := request.Header.Get("X-Tenant"); got != "" {
+		t.Errorf("caller's request X-Tenant = %q, want it untouched", got)
+	}
+}
@@ -0,0 +1,210 @@
+package todoist
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+const (
+	OAUTH_AUTHORIZE_URL = "https://todoist.com/oauth/authorize"
+	OAUTH_TOKEN_URL     = "https://todoist.com/oauth/access_token"
+	OAUTH_REVOKE_URL    = SYNC_BASE_URL + "/access_tokens/revoke"
+
+	SCOPE_TASK_ADD        = "task:add"
+	SCOPE_DATA_READ       = "data:read"
+	SCOPE_DATA_READ_WRITE = "data:read_write"
+	SCOPE_DATA_DELETE     = "data:delete"
+	SCOPE_PROJECT_DELETE  = "project:delete"
+)
+
+// TokenSource provides the bearer token sent with each request.
+type TokenSource interface {
+	Token(ctx context.Context) (string, error)
+}
+
+// NewWithTokenSource returns a client asking source for a token before each
+// request, instead of using a fixed one as New does.
+func NewWithTokenSource(source TokenSource, opts ...Option) Todoist {
+	return New("", append([]Option{WithTokenSource(source)}, opts...)...)
+}
+
+// OAuthConfig describes a Todoist app registered in the App Management
+// Console. The endpoint URLs default to Todoist's, and may point at a local
+// stand-in authorization server in tests.
+type OAuthConfig struct {
+	ClientID     string
+	ClientSecret string
+	Scopes       []string
+
+	AuthorizeURL string
+	TokenURL     string
+	RevokeURL    string
+
+	// HTTPClient is used for token exchange and revocation. It defaults to a
+	// client with MAX_TIMEOUT.
+	HTTPClient *http.Client
+}
+
+// OAuthToken is an access token obtained through the OAuth flow. It is a
+// TokenSource, so it can be given to NewWithTokenSource directly.
+type OAuthToken struct {
+	AccessToken string `json:"access_token"`
+	TokenType   string `json:"token_type"`
+}
+
+func (t OAuthToken) Token(context.Context) (string, error) {
+	if t.AccessToken == "" {
+		return "", errors.New("OAuth token is empty")
+	}
+
+	return t.AccessToken, nil
+}
+
+// OAuthError is returned when the authorization server refuses a request,
+// e.g. with "bad_authorization_code".
+type OAuthError struct {
+	Code string `json:"error"`
+}
+
+func (e *OAuthError) Error() string {
+	return fmt.Sprintf("OAuth request failed: %s", e.Code)
+}
+
+// AuthCodeURL returns the URL to redirect users to so they authorize the app.
+// state must be an unguessable value, checked when Todoist redirects back.
+func (c OAuthConfig) AuthCodeURL(state string) string {
+	authorizeURL := c.AuthorizeURL
+	if authorizeURL == "" {
+		authorizeURL = OAUTH_AUTHORIZE_URL
+	}
+
+	query := url.Values{
+		"client_id": {c.ClientID},
+		"scope":     {strings.Join(c.Scopes, ",")},
+		"state":     {state},
+	}
+
+	separator := "?"
+	if strings.Contains(authorizeURL, "?") {
+		separator = "&"
+	}
+
+	return authorizeURL + separator + query.Encode()
+}
+
+// Exchange trades the code Todoist redirected back with for an access token.
+func (c OAuthConfig) Exchange(ctx context.Context, code string) (OAuthToken, error) {
+	if code == "" {
+		return OAuthToken{}, errors.New("code is required")
+	}
+
+	tokenURL := c.TokenURL
+	if tokenURL == "" {
+		tokenURL = OAUTH_TOKEN_URL
+	}
+
+	form := url.Values{
+		"client_id":     {c.ClientID},
+		"client_secret": {c.ClientSecret},
+		"code":          {code},
+	}
+
+	request, err := http.NewRequestWithContext(ctx, http.MethodPost, tokenURL, strings.NewReader(form.Encode()))
+	if err != nil {
+		return OAuthToken{}, err
+	}
+
+	request.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	response, err := c.httpClient().Do(request)
+	if err != nil {
+		return OAuthToken{}, err
+	}
+	defer response.Body.Close()
+
+	data, err := io.ReadAll(response.Body)
+	if err != nil {
+		return OAuthToken{}, err
+	}
+
+	var oauthErr OAuthError
+	if json.Unmarshal(data, &oauthErr) == nil && oauthErr.Code != "" {
+		return OAuthToken{}, &oauthErr
+	}
+
+	if response.StatusCode != http.StatusOK {
+		response.Body = io.NopCloser(strings.NewReader(string(data)))
+		return OAuthToken{}, newAPIError(response)
+	}
+
+	var token OAuthToken
+	if err := json.Unmarshal(data, &token); err != nil {
+		return OAuthToken{}, err
+	}
+
+	if token.AccessToken == "" {
+		return OAuthToken{}, errors.New("no access token in OAuth response")
+	}
+
+	return token, nil
+}
+
+// Revoke invalidates an access token issued to the app.
+func (c OAuthConfig) Revoke(ctx context.Context, accessToken string) error {
+	if accessToken == "" {
+		return errors.New("access token is required")
+	}
+
+	revokeURL := c.RevokeURL
+	if revokeURL == "" {
+		revokeURL = OAUTH_REVOKE_URL
+	}
+
+	bodyRequest, err := json.Marshal(map[string]string{
+		"client_id":     c.ClientID,
+		"client_secret": c.ClientSecret,
+		"access_token":  accessToken,
+	})
+	if err != nil {
+		return err
+	}
+
+	request, err := http.NewRequestWithContext(ctx, http.MethodPost, revokeURL, strings.NewReader(string(bodyRequest)))
+	if err != nil {
+		return err
+	}
+
+	request.Header.Set("Content-Type", "application/json")
+
+	response, err := c.httpClient().Do(request)
+	if err != nil {
+		return err
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode != http.StatusOK && response.StatusCode != http.StatusNoContent {
+		return newAPIError(response)
+	}
+
+	return nil
+}
+
+// Client returns a client acting on behalf of the user the token was issued
+// for. Building one per user is cheap, as clients are plain values.
+func (c OAuthConfig) Client(token OAuthToken, opts ...Option) Todoist {
+	return NewWithTokenSource(token, opts...)
+}
+
+func (c OAuthConfig) httpClient() *http.Client {
+	if c.HTTPClient != nil {
+		return c.HTTPClient
+	}
+
+	return &http.Client{Timeout: MAX_TIMEOUT}
+}
@@ -0,0 +1,168 @@
+package todoist
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"reflect"
+	"testing"
+)
+
+func TestAuthCodeURL(t *testing.T) {
+	config := OAuthConfig{ClientID: "client", Scopes: []string{SCOPE_DATA_READ, SCOPE_TASK_ADD}}
+
+	tests := []struct {
+		authorizeURL string
+		wantBase     string
+		wantExtra    url.Values
+	}{
+		{"", OAUTH_AUTHORIZE_URL, nil},
+		{"http://localhost:9000/authorize", "http://localhost:9000/authorize", nil},
+		{"http://localhost:9000/authorize?tenant=acme", "http://localhost:9000/authorize", url.Values{"tenant": {"acme"}}},
+	}
+
+	for _, tt := range tests {
+		config.AuthorizeURL = tt.authorizeURL
+
+		parsed, err := url.Parse(config.AuthCodeURL("state with spaces"))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		query := parsed.Query()
+		parsed.RawQuery = ""
+		if parsed.String() != tt.wantBase {
+			t.Errorf("%q: base = %s, want %s", tt.authorizeURL, parsed, tt.wantBase)
+		}
+
+		want := url.Values{"client_id": {"client"}, "scope": {"data:read,task:add"}, "state": {"state with spaces"}}
+		for key, values := range tt.wantExtra {
+			want[key] = values
+		}
+		if query.Encode() != want.Encode() {
+			t.Errorf("%q: query = %v, want %v", tt.authorizeURL, query, want)
+		}
+	}
+}
+
+// authServer stands in for Todoist's authorization server. The token
+// endpoint answers with status and body; the forms it receives and the
+// revocation requests are recorded.
+type authServer struct {
+	*httptest.Server
+	forms   []url.Values
+	revoked []map[string]string
+}
+
+func newAuthServer(t *testing.T, status int, body string) *authServer {
+	s := &authServer{}
+	s.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/oauth/access_token":
+			r.ParseForm()
+			s.forms = append(s.forms, r.PostForm)
+			w.WriteHeader(status)
+			fmt.Fprint(w, body)
+		case "/access_tokens/revoke":
+			var args map[string]string
+			json.NewDecoder(r.Body).Decode(&args)
+			s.revoked = append(s.revoked, args)
+			if args["access_token"] == "unknown" {
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	t.Cleanup(s.Close)
+
+	return s
+}
+
+func (s *authServer) config() OAuthConfig {
+	return OAuthConfig{
+		ClientID:     "client",
+		ClientSecret: "secret",
+		TokenURL:     s.URL + "/oauth/access_token",
+		RevokeURL:    s.URL + "/access_tokens/revoke",
+	}
+}
+
+func TestExchange(t *testing.T) {
+	server := newAuthServer(t, http.StatusOK, `{"access_token": "0123456789abcdef", "token_type": "Bearer"}`)
+
+	token, err := server.config().Exchange(context.Background(), "code")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if token != (OAuthToken{AccessToken: "0123456789abcdef", TokenType: "Bearer"}) {
+		t.Errorf("token = %+v", token)
+	}
+
+	want := url.Values{"client_id": {"client"}, "client_secret": {"secret"}, "code": {"code"}}
+	if len(server.forms) != 1 || server.forms[0].Encode() != want.Encode() {
+		t.Errorf("forms = %v, want %v", server.forms, want)
+	}
+}
+
+func TestExchangeErrors(t *testing.T) {
+	tests := []struct {
+		name   string
+		status int
+		body   string
+		check  func(error) bool
+	}{
+		{"oauth error", http.StatusBadRequest, `{"error": "bad_authorization_code"}`, func(err error) bool {
+			var oauthErr *OAuthError
+			return errors.As(err, &oauthErr) && oauthErr.Code == "bad_authorization_code"
+		}},
+		{"oauth error with 200", http.StatusOK, `{"error": "bad_authorization_code"}`, func(err error) bool {
+			var oauthErr *OAuthError
+			return errors.As(err, &oauthErr)
+		}},
+		{"server error", http.StatusServiceUnavailable, "Service Unavailable", func(err error) bool {
+			var apiErr *APIError
+			return errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusServiceUnavailable
+		}},
+		{"no token", http.StatusOK, `{}`, func(err error) bool { return err != nil }},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := newAuthServer(t, tt.status, tt.body)
+
+			if _, err := server.config().Exchange(context.Background(), "code"); !tt.check(err) {
+				t.Errorf("unexpected error %v", err)
+			}
+		})
+	}
+}
+
+func TestRevoke(t *testing.T) {
+	server := newAuthServer(t, http.StatusOK, "")
+	config := server.config()
+
+	if err := config.Revoke(context.Background(), "0123456789abcdef"); err != nil {
+		t.Fatal(err)
+	}
+
+	want := map[string]string{"client_id": "client", "client_secret": "secret", "access_token": "0123456789abcdef"}
+	if len(server.revoked) != 1 || !reflect.DeepEqual(server.revoked[0], want) {
+		t.Errorf("revoked = %v, want %v", server.revoked, want)
+	}
+
+	var apiErr *APIError
+	if err := config.Revoke(context.Background(), "unknown"); !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusBadRequest {
+		t.Errorf("revoking an unknown token: got %v, want a 400 APIError", err)
+	}
+	if err := config.Revoke(context.Background(), ""); err == nil {
+		t.Error("revoking an empty token: want an error")
+	}
+}
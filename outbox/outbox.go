@@ -0,0 +1,240 @@
+// Package outbox queues Todoist mutations while offline and replays them, in
+// order and with their original idempotency keys, once connectivity returns.
+package outbox
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"sync"
+	"time"
+
+	todoist "github.com/felipeornelis/todoist-go-client"
+	"github.com/felipeornelis/todoist-go-client/pkg"
+)
+
+type Operation string
+
+const (
+	ADD_TASK       Operation = "add_task"
+	UPDATE_TASK    Operation = "update_task"
+	CLOSE_TASK     Operation = "close_task"
+	REOPEN_TASK    Operation = "reopen_task"
+	DELETE_TASK    Operation = "delete_task"
+	ADD_COMMENT    Operation = "add_comment"
+	UPDATE_COMMENT Operation = "update_comment"
+	DELETE_COMMENT Operation = "delete_comment"
+	ADD_PROJECT    Operation = "add_project"
+	UPDATE_PROJECT Operation = "update_project"
+	DELETE_PROJECT Operation = "delete_project"
+	ADD_SECTION    Operation = "add_section"
+	UPDATE_SECTION Operation = "update_section"
+	DELETE_SECTION Operation = "delete_section"
+)
+
+// Entry is a queued mutation. Its ID is sent as X-Request-Id on every replay
+// attempt, and may be used in place of the ID of the resource it creates by
+// entries queued after it.
+type Entry struct {
+	ID        string          `json:"id"`
+	Operation Operation       `json:"operation"`
+	TargetID  string          `json:"target_id,omitempty"`
+	Args      json.RawMessage `json:"args,omitempty"`
+	QueuedAt  time.Time       `json:"queued_at"`
+	Attempts  int             `json:"attempts"`
+	LastError string          `json:"last_error,omitempty"`
+}
+
+type state struct {
+	Entries []Entry `json:"entries"`
+	// Created maps the ID of replayed creation entries to the ID of the
+	// resource they created.
+	Created map[string]string `json:"created"`
+}
+
+// Outbox is a durable FIFO queue of mutations stored in a JSON file. It is
+// safe for concurrent use.
+type Outbox struct {
+	// Resolver decides what to do with entries that conflict with the
+	// server state. It defaults to AbortOnConflict.
+	Resolver ConflictResolver
+
+	path string
+
+	mu    sync.Mutex
+	state state
+
+	// replayMu keeps replays from running concurrently.
+	replayMu sync.Mutex
+}
+
+// Open loads the outbox stored at path, creating an empty one if the file
+// does not exist.
+func Open(path string) (*Outbox, error) {
+	o := &Outbox{
+		path:  path,
+		state: state{Created: map[string]string{}},
+	}
+
+	data, err := os.ReadFile(path)
+	switch {
+	case errors.Is(err, os.ErrNotExist):
+	case err != nil:
+		return nil, err
+	default:
+		if err := json.Unmarshal(data, &o.state); err != nil {
+			return nil, err
+		}
+		if o.state.Created == nil {
+			o.state.Created = map[string]string{}
+		}
+	}
+
+	return o, nil
+}
+
+// Pending returns the entries waiting to be replayed, oldest first.
+func (o *Outbox) Pending() []Entry {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	return append([]Entry(nil), o.state.Entries...)
+}
+
+func (o *Outbox) Len() int {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	return len(o.state.Entries)
+}
+
+func (o *Outbox) enqueue(operation Operation, targetID string, args any) (Entry, error) {
+	entry := Entry{
+		ID:        pkg.NewUUID(),
+		Operation: operation,
+		TargetID:  targetID,
+		QueuedAt:  time.Now().UTC(),
+	}
+
+	if args != nil {
+		data, err := json.Marshal(args)
+		if err != nil {
+			return Entry{}, err
+		}
+		entry.Args = data
+	}
+
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	o.state.Entries = append(o.state.Entries, entry)
+	if err := o.save(); err != nil {
+		o.state.Entries = o.state.Entries[:len(o.state.Entries)-1]
+		return Entry{}, err
+	}
+
+	return entry, nil
+}
+
+// Remove drops an entry without replaying it.
+func (o *Outbox) Remove(id string) error {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	for i, entry := range o.state.Entries {
+		if entry.ID == id {
+			o.state.Entries = append(o.state.Entries[:i:i], o.state.Entries[i+1:]...)
+			return o.save()
+		}
+	}
+
+	return nil
+}
+
+// save must be called with o.mu held. The file is replaced atomically.
+func (o *Outbox) save() error {
+	data, err := json.Marshal(o.state)
+	if err != nil {
+		return err
+	}
+
+	return pkg.WriteFileAtomic(o.path, data)
+}
+
+func (o *Outbox) AddTask(args todoist.AddTaskArgs) (Entry, error) {
+	if args.Content == "" {
+		return Entry{}, errors.New("`Content` field is required")
+	}
+	return o.enqueue(ADD_TASK, "", args)
+}
+
+func (o *Outbox) UpdateTask(args todoist.UpdateTaskArgs, id string) (Entry, error) {
+	return o.enqueueFor(UPDATE_TASK, id, args)
+}
+
+func (o *Outbox) CloseTask(id string) (Entry, error) {
+	return o.enqueueFor(CLOSE_TASK, id, nil)
+}
+
+func (o *Outbox) ReopenTask(id string) (Entry, error) {
+	return o.enqueueFor(REOPEN_TASK, id, nil)
+}
+
+func (o *Outbox) DeleteTask(id string) (Entry, error) {
+	return o.enqueueFor(DELETE_TASK, id, nil)
+}
+
+func (o *Outbox) AddComment(args todoist.AddCommentArgs) (Entry, error) {
+	if args.TaskID == "" && args.ProjectID == "" {
+		return Entry{}, errors.New("task_id or project_id is required")
+	}
+	return o.enqueue(ADD_COMMENT, "", args)
+}
+
+func (o *Outbox) UpdateComment(id string, args todoist.UpdateCommentArgs) (Entry, error) {
+	return o.enqueueFor(UPDATE_COMMENT, id, args)
+}
+
+func (o *Outbox) DeleteComment(id string) (Entry, error) {
+	return o.enqueueFor(DELETE_COMMENT, id, nil)
+}
+
+func (o *Outbox) AddProject(args todoist.AddProjectArgs) (Entry, error) {
+	if args.Name == "" {
+		return Entry{}, errors.New("`name` is required")
+	}
+	return o.enqueue(ADD_PROJECT, "", args)
+}
+
+func (o *Outbox) UpdateProject(args todoist.UpdateProjectArgs, id string) (Entry, error) {
+	return o.enqueueFor(UPDATE_PROJECT, id, args)
+}
+
+func (o *Outbox) DeleteProject(id string) (Entry, error) {
+	return o.enqueueFor(DELETE_PROJECT, id, nil)
+}
+
+func (o *Outbox) AddSection(args todoist.AddSectionArgs) (Entry, error) {
+	if args.Name == "" || args.ProjectID == "" {
+		return Entry{}, errors.New("`name` and `project_id` are required")
+	}
+	return o.enqueue(ADD_SECTION, "", args)
+}
+
+func (o *Outbox) UpdateSection(args todoist.UpdateSectionArgs, id string) (Entry, error) {
+	if args.Name == "" {
+		return Entry{}, errors.New("Name field is required")
+	}
+	return o.enqueueFor(UPDATE_SECTION, id, args)
+}
+
+func (o *Outbox) DeleteSection(id string) (Entry, error) {
+	return o.enqueueFor(DELETE_SECTION, id, nil)
+}
+
+func (o *Outbox) enqueueFor(operation Operation, id string, args any) (Entry, error) {
+	if id == "" {
+		return Entry{}, errors.New("ID is required")
+	}
+	return o.enqueue(operation, id, args)
+}
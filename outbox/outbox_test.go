@@ -0,0 +1,206 @@
+package outbox_test
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"testing"
+
+	todoist "github.com/felipeornelis/todoist-go-client"
+	"github.com/felipeornelis/todoist-go-client/outbox"
+	"github.com/felipeornelis/todoist-go-client/todoisttest"
+)
+
+func open(t *testing.T, path string) *outbox.Outbox {
+	t.Helper()
+
+	o, err := outbox.Open(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return o
+}
+
+// must returns a function failing the test when enqueuing failed.
+func must(t *testing.T) func(outbox.Entry, error) outbox.Entry {
+	return func(entry outbox.Entry, err error) outbox.Entry {
+		t.Helper()
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		return entry
+	}
+}
+
+func TestReplayResolvesCreatedIDs(t *testing.T) {
+	server := todoisttest.NewServer()
+	defer server.Close()
+	client := server.Client()
+
+	o := open(t, filepath.Join(t.TempDir(), "outbox.json"))
+
+	project := must(t)(o.AddProject(todoist.AddProjectArgs{Name: "Trip"}))
+	section := must(t)(o.AddSection(todoist.AddSectionArgs{Name: "Packing", ProjectID: project.ID}))
+	task := must(t)(o.AddTask(todoist.AddTaskArgs{Content: "Passport", ProjectID: project.ID, SectionID: section.ID}))
+	must(t)(o.AddComment(todoist.AddCommentArgs{TaskID: task.ID, Content: "In the drawer"}))
+	must(t)(o.UpdateTask(todoist.UpdateTaskArgs{Content: "Passport and visa"}, task.ID))
+
+	report, err := o.Replay(context.Background(), client)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []outbox.Operation{outbox.ADD_PROJECT, outbox.ADD_SECTION, outbox.ADD_TASK, outbox.ADD_COMMENT, outbox.UPDATE_TASK}
+	if len(report.Replayed) != len(want) {
+		t.Fatalf("replayed %d entries, want %d", len(report.Replayed), len(want))
+	}
+	for i, entry := range report.Replayed {
+		if entry.Operation != want[i] {
+			t.Errorf("entry %d is %s, want %s", i, entry.Operation, want[i])
+		}
+	}
+	if o.Len() != 0 {
+		t.Errorf("%d entries left queued", o.Len())
+	}
+
+	projects, _ := client.GetProjects()
+	var projectID string
+	for _, p := range projects {
+		if p.Name == "Trip" {
+			projectID = p.ID
+		}
+	}
+
+	tasks, err := client.GetTasks(todoist.GetTasksArgs{ProjectID: projectID})
+	if err != nil || len(tasks) != 1 {
+		t.Fatalf("got %+v, %v, want one task in the created project", tasks, err)
+	}
+	if tasks[0].Content != "Passport and visa" || tasks[0].SectionID == "" || tasks[0].SectionID == section.ID {
+		t.Errorf("got %+v", tasks[0])
+	}
+
+	comments, err := client.GetComments(todoist.GetCommentsArgs{TaskID: tasks[0].ID})
+	if err != nil || len(comments) != 1 {
+		t.Errorf("got comments %+v, %v", comments, err)
+	}
+}
+
+func TestReplayAcrossRestarts(t *testing.T) {
+	server := todoisttest.NewServer()
+	defer server.Close()
+
+	path := filepath.Join(t.TempDir(), "outbox.json")
+
+	o := open(t, path)
+	project := must(t)(o.AddProject(todoist.AddProjectArgs{Name: "Trip"}))
+
+	// Unreachable server: the entry stays queued with its failed attempt.
+	offline := todoist.New(todoisttest.DEFAULT_TOKEN, todoist.WithBaseURL("http://127.0.0.1:1"))
+	if _, err := o.Replay(context.Background(), offline); err == nil {
+		t.Fatal("replay against an unreachable server succeeded")
+	}
+
+	o = open(t, path)
+	pending := o.Pending()
+	if len(pending) != 1 || pending[0].ID != project.ID || pending[0].Attempts != 1 || pending[0].LastError == "" {
+		t.Fatalf("got pending %+v", pending)
+	}
+
+	if _, err := o.Replay(context.Background(), server.Client()); err != nil {
+		t.Fatal(err)
+	}
+
+	// Entries queued after a restart still resolve the created project.
+	o = open(t, path)
+	must(t)(o.AddTask(todoist.AddTaskArgs{Content: "Passport", ProjectID: project.ID}))
+	if _, err := o.Replay(context.Background(), server.Client()); err != nil {
+		t.Fatal(err)
+	}
+
+	tasks, _ := server.Client().GetTasks(todoist.GetTasksArgs{})
+	if len(tasks) != 1 || tasks[0].ProjectID == project.ID || tasks[0].ProjectID == server.InboxID() {
+		t.Errorf("got %+v, want the task in the created project", tasks)
+	}
+}
+
+func TestReplayAlreadyApplied(t *testing.T) {
+	server := todoisttest.NewServer()
+	defer server.Close()
+	client := server.Client()
+
+	task, err := client.AddTask(todoist.AddTaskArgs{Content: "Old"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	o := open(t, filepath.Join(t.TempDir(), "outbox.json"))
+	must(t)(o.CloseTask(task.ID))
+	must(t)(o.CloseTask(task.ID))
+	must(t)(o.DeleteTask(task.ID))
+	must(t)(o.DeleteTask(task.ID))
+	must(t)(o.CloseTask(task.ID))
+	must(t)(o.DeleteProject("404"))
+
+	report, err := o.Replay(context.Background(), client)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(report.Replayed) != 6 || len(report.Conflicts) != 0 || o.Len() != 0 {
+		t.Errorf("replayed %d entries with %d conflicts, %d left", len(report.Replayed), len(report.Conflicts), o.Len())
+	}
+}
+
+func TestReplayConflicts(t *testing.T) {
+	server := todoisttest.NewServer()
+	defer server.Close()
+	client := server.Client()
+
+	completed, err := client.AddTask(todoist.AddTaskArgs{Content: "Done elsewhere"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	o := open(t, filepath.Join(t.TempDir(), "outbox.json"))
+	deleted := must(t)(o.UpdateTask(todoist.UpdateTaskArgs{Content: "Gone"}, "404"))
+	must(t)(o.CloseTask(completed.ID))
+	must(t)(o.UpdateTask(todoist.UpdateTaskArgs{Content: "Done here"}, completed.ID))
+	must(t)(o.AddTask(todoist.AddTaskArgs{Content: "New"}))
+
+	if err := client.CloseTask(completed.ID); err != nil {
+		t.Fatal(err)
+	}
+
+	report, err := o.Replay(context.Background(), client)
+	if !errors.Is(err, outbox.ErrConflict) {
+		t.Fatalf("got %v, want ErrConflict", err)
+	}
+	if len(report.Conflicts) != 1 || report.Conflicts[0].Kind != outbox.CONFLICT_DELETED {
+		t.Fatalf("got conflicts %+v", report.Conflicts)
+	}
+	if pending := o.Pending(); len(pending) != 4 || pending[0].ID != deleted.ID {
+		t.Fatalf("conflicting entry not kept first: %+v", pending)
+	}
+
+	var kinds []outbox.ConflictKind
+	o.Resolver = func(ctx context.Context, client todoist.Todoist, conflict outbox.Conflict) outbox.Resolution {
+		kinds = append(kinds, conflict.Kind)
+		return outbox.SKIP
+	}
+
+	report, err = o.Replay(context.Background(), client)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if want := []outbox.ConflictKind{outbox.CONFLICT_DELETED, outbox.CONFLICT_COMPLETED}; len(kinds) != 2 || kinds[0] != want[0] || kinds[1] != want[1] {
+		t.Errorf("got conflicts %v, want %v", kinds, want)
+	}
+	// Closing the task completed meanwhile is what the entry asked for.
+	if len(report.Skipped) != 2 || len(report.Replayed) != 2 || o.Len() != 0 {
+		t.Errorf("skipped %d, replayed %d, %d left", len(report.Skipped), len(report.Replayed), o.Len())
+	}
+}
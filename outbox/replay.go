@@ -0,0 +1,352 @@
+package outbox
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+
+	todoist "github.com/felipeornelis/todoist-go-client"
+)
+
+var ErrConflict = errors.New("outbox: unresolved conflict")
+
+type ConflictKind string
+
+const (
+	// CONFLICT_DELETED means the resource targeted by the entry no longer
+	// exists. Deletes of a resource already gone, and closes of a task
+	// already completed or gone, are not conflicts.
+	CONFLICT_DELETED ConflictKind = "deleted"
+	// CONFLICT_COMPLETED means the task targeted by an update was completed
+	// by someone else meanwhile.
+	CONFLICT_COMPLETED ConflictKind = "completed"
+	// CONFLICT_REJECTED means Todoist refused the mutation, e.g. because its
+	// arguments are no longer valid.
+	CONFLICT_REJECTED ConflictKind = "rejected"
+)
+
+// Conflict describes an entry whose replay diverged from the server state.
+type Conflict struct {
+	Entry Entry
+	Kind  ConflictKind
+	// Task is the current server state of the targeted task, when known.
+	Task *todoist.Task
+	Err  error
+}
+
+type Resolution int
+
+const (
+	// KEEP leaves the entry queued and stops the replay.
+	KEEP Resolution = iota
+	// SKIP drops the entry and carries on with the next one.
+	SKIP
+	// OVERWRITE applies the entry regardless of the conflict.
+	OVERWRITE
+)
+
+// ConflictResolver decides how a conflicting entry is handled. It may use
+// client to inspect or fix the server state first.
+type ConflictResolver func(ctx context.Context, client todoist.Todoist, conflict Conflict) Resolution
+
+// AbortOnConflict keeps every conflicting entry for a human to look at.
+func AbortOnConflict(context.Context, todoist.Todoist, Conflict) Resolution {
+	return KEEP
+}
+
+// SkipConflicts drops every conflicting entry.
+func SkipConflicts(context.Context, todoist.Todoist, Conflict) Resolution {
+	return SKIP
+}
+
+type ReplayReport struct {
+	Replayed  []Entry
+	Skipped   []Entry
+	Conflicts []Conflict
+}
+
+// Replay sends the pending entries in order. It stops at the first network
+// or server error, leaving the remaining entries queued for the next
+// attempt, and at the first conflict the Resolver keeps, returning an error
+// wrapping ErrConflict.
+func (o *Outbox) Replay(ctx context.Context, client todoist.Todoist) (ReplayReport, error) {
+	o.replayMu.Lock()
+	defer o.replayMu.Unlock()
+
+	resolver := o.Resolver
+	if resolver == nil {
+		resolver = AbortOnConflict
+	}
+
+	var report ReplayReport
+
+	for {
+		o.mu.Lock()
+		if len(o.state.Entries) == 0 {
+			o.mu.Unlock()
+			return report, nil
+		}
+		entry, err := o.resolve(o.state.Entries[0])
+		o.mu.Unlock()
+		if err != nil {
+			return report, err
+		}
+
+		conflict, err := check(ctx, client, entry)
+		if err != nil {
+			return report, o.fail(entry, err)
+		}
+
+		var createdID string
+		if conflict == nil {
+			createdID, err = apply(ctx, client, entry)
+			if alreadyApplied(entry, err) {
+				err = nil
+			}
+			if conflict = asConflict(entry, err); conflict == nil && err != nil {
+				return report, o.fail(entry, err)
+			}
+		}
+
+		if conflict != nil {
+			report.Conflicts = append(report.Conflicts, *conflict)
+
+			switch resolver(ctx, client, *conflict) {
+			case SKIP:
+				report.Skipped = append(report.Skipped, entry)
+				if err := o.done(entry, ""); err != nil {
+					return report, err
+				}
+				continue
+			case OVERWRITE:
+				if createdID, err = apply(ctx, client, entry); err != nil {
+					return report, o.fail(entry, err)
+				}
+			default:
+				o.fail(entry, conflict.Err)
+				return report, fmt.Errorf("%w: %s entry %s: %s", ErrConflict, entry.Operation, entry.ID, conflict.Kind)
+			}
+		}
+
+		report.Replayed = append(report.Replayed, entry)
+		if err := o.done(entry, createdID); err != nil {
+			return report, err
+		}
+	}
+}
+
+// resolve replaces the IDs of creation entries already replayed with the IDs
+// of the resources they created. It must be called with o.mu held.
+func (o *Outbox) resolve(entry Entry) (Entry, error) {
+	if id, ok := o.state.Created[entry.TargetID]; ok {
+		entry.TargetID = id
+	}
+
+	if len(entry.Args) == 0 {
+		return entry, nil
+	}
+
+	var args map[string]any
+	if err := json.Unmarshal(entry.Args, &args); err != nil {
+		return entry, err
+	}
+
+	for _, key := range []string{"task_id", "project_id", "section_id", "parent_id"} {
+		if value, ok := args[key].(string); ok {
+			if id, ok := o.state.Created[value]; ok {
+				args[key] = id
+			}
+		}
+	}
+
+	data, err := json.Marshal(args)
+	if err != nil {
+		return entry, err
+	}
+	entry.Args = data
+
+	return entry, nil
+}
+
+// done removes the replayed entry and records the ID it created, if any.
+func (o *Outbox) done(entry Entry, createdID string) error {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	if createdID != "" {
+		o.state.Created[entry.ID] = createdID
+	}
+
+	for i, pending := range o.state.Entries {
+		if pending.ID == entry.ID {
+			o.state.Entries = append(o.state.Entries[:i:i], o.state.Entries[i+1:]...)
+			break
+		}
+	}
+
+	return o.save()
+}
+
+// fail records the failed attempt and returns err.
+func (o *Outbox) fail(entry Entry, err error) error {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	for i := range o.state.Entries {
+		if o.state.Entries[i].ID == entry.ID {
+			o.state.Entries[i].Attempts++
+			if err != nil {
+				o.state.Entries[i].LastError = err.Error()
+			}
+			break
+		}
+	}
+
+	if saveErr := o.save(); saveErr != nil {
+		return errors.Join(err, saveErr)
+	}
+
+	return err
+}
+
+// check looks for a conflict before replaying task updates, which Todoist
+// would otherwise happily apply to a completed task. The task is looked up
+// with GetTaskInfo, since GetTask does not find completed tasks.
+func check(ctx context.Context, client todoist.Todoist, entry Entry) (*Conflict, error) {
+	if entry.Operation != UPDATE_TASK {
+		return nil, nil
+	}
+
+	task, err := client.GetTaskInfoWithContext(ctx, entry.TargetID)
+	if conflict := asConflict(entry, err); conflict != nil {
+		return conflict, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if task.IsCompleted {
+		return &Conflict{
+			Entry: entry,
+			Kind:  CONFLICT_COMPLETED,
+			Task:  &task,
+			Err:   fmt.Errorf("task %s was completed meanwhile", task.ID),
+		}, nil
+	}
+
+	return nil, nil
+}
+
+// asConflict turns client errors meaning the server state diverged into a
+// conflict. Other errors, e.g. network failures, are left to be retried.
+func asConflict(entry Entry, err error) *Conflict {
+	var apiErr *todoist.APIError
+	if !errors.As(err, &apiErr) {
+		return nil
+	}
+
+	switch {
+	case apiErr.StatusCode == http.StatusNotFound || apiErr.StatusCode == http.StatusGone:
+		return &Conflict{Entry: entry, Kind: CONFLICT_DELETED, Err: err}
+	case apiErr.StatusCode >= 400 && apiErr.StatusCode < 500 && apiErr.StatusCode != http.StatusTooManyRequests &&
+		apiErr.StatusCode != http.StatusUnauthorized && apiErr.StatusCode != http.StatusForbidden:
+		return &Conflict{Entry: entry, Kind: CONFLICT_REJECTED, Err: err}
+	}
+
+	return nil
+}
+
+// alreadyApplied reports whether a delete or close failed because the
+// resource is already gone or the task already completed, which is what the
+// entry asked for. The REST API answers 404 for both.
+func alreadyApplied(entry Entry, err error) bool {
+	switch entry.Operation {
+	case DELETE_TASK, DELETE_COMMENT, DELETE_PROJECT, DELETE_SECTION, CLOSE_TASK:
+	default:
+		return false
+	}
+
+	var apiErr *todoist.APIError
+	return errors.As(err, &apiErr) && (apiErr.StatusCode == http.StatusNotFound || apiErr.StatusCode == http.StatusGone)
+}
+
+// apply replays the entry with its ID as idempotency key and returns the ID
+// of the resource it created, if any.
+func apply(ctx context.Context, client todoist.Todoist, entry Entry) (string, error) {
+	ctx = todoist.ContextWithRequestID(ctx, entry.ID)
+
+	switch entry.Operation {
+	case ADD_TASK:
+		var args todoist.AddTaskArgs
+		if err := json.Unmarshal(entry.Args, &args); err != nil {
+			return "", err
+		}
+		task, err := client.AddTaskWithContext(ctx, args)
+		return task.ID, err
+	case UPDATE_TASK:
+		var args todoist.UpdateTaskArgs
+		if err := json.Unmarshal(entry.Args, &args); err != nil {
+			return "", err
+		}
+		_, err := client.UpdateTaskWithContext(ctx, args, entry.TargetID)
+		return "", err
+	case CLOSE_TASK:
+		return "", client.CloseTaskWithContext(ctx, entry.TargetID)
+	case REOPEN_TASK:
+		return "", client.ReopenTaskWithContext(ctx, entry.TargetID)
+	case DELETE_TASK:
+		return "", client.DeleteTaskWithContext(ctx, entry.TargetID)
+	case ADD_COMMENT:
+		var args todoist.AddCommentArgs
+		if err := json.Unmarshal(entry.Args, &args); err != nil {
+			return "", err
+		}
+		comment, err := client.AddCommentWithContext(ctx, args)
+		return comment.ID, err
+	case UPDATE_COMMENT:
+		var args todoist.UpdateCommentArgs
+		if err := json.Unmarshal(entry.Args, &args); err != nil {
+			return "", err
+		}
+		_, err := client.UpdateCommentWithContext(ctx, entry.TargetID, args)
+		return "", err
+	case DELETE_COMMENT:
+		return "", client.DeleteCommentWithContext(ctx, entry.TargetID)
+	case ADD_PROJECT:
+		var args todoist.AddProjectArgs
+		if err := json.Unmarshal(entry.Args, &args); err != nil {
+			return "", err
+		}
+		project, err := client.AddProjectWithContext(ctx, args)
+		return project.ID, err
+	case UPDATE_PROJECT:
+		var args todoist.UpdateProjectArgs
+		if err := json.Unmarshal(entry.Args, &args); err != nil {
+			return "", err
+		}
+		_, err := client.UpdateProjectWithContext(ctx, args, entry.TargetID)
+		return "", err
+	case DELETE_PROJECT:
+		return "", client.DeleteProjectWithContext(ctx, entry.TargetID)
+	case ADD_SECTION:
+		var args todoist.AddSectionArgs
+		if err := json.Unmarshal(entry.Args, &args); err != nil {
+			return "", err
+		}
+		section, err := client.AddSectionWithContext(ctx, args)
+		return section.ID, err
+	case UPDATE_SECTION:
+		var args todoist.UpdateSectionArgs
+		if err := json.Unmarshal(entry.Args, &args); err != nil {
+			return "", err
+		}
+		_, err := client.UpdateSectionWithContext(ctx, args, entry.TargetID)
+		return "", err
+	case DELETE_SECTION:
+		return "", client.DeleteSectionWithContext(ctx, entry.TargetID)
+	}
+
+	return "", fmt.Errorf("outbox: unknown operation %q", entry.Operation)
+}
@@ -2,52 +2,58 @@ package todoist
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
 	"io"
 	"net/http"
-
-	"github.com/felipeornelis/todoist-go-client/pkg"
+	"net/url"
 )
 
-const PROJECT_URL = BASE_URL + "/projects"
+const (
+	PROJECT_PATH = "/projects"
+	PROJECT_URL  = BASE_URL + PROJECT_PATH
+
+	ARCHIVED_PROJECTS_PATH = "/projects/get_archived"
+)
 
 type Project struct {
 	ID             string `json:"id"`
 	Name           string `json:"name"`
 	Color          string `json:"color"`
 	ParentID       string `json:"parent_id"`
-	Order          uint8  `json:"order"`
+	Order          int    `json:"order"`
 	CommentCount   int    `json:"comment_count"`
 	IsShared       bool   `json:"is_shared"`
 	IsFavorite     bool   `json:"is_favorite"`
 	IsInboxProject bool   `json:"is_inbox_project"`
 	IsTeamInbox    bool   `json:"is_team_inbox"`
+	IsArchived     bool   `json:"is_archived"`
 	ViewStyle      string `json:"view_style"`
 	URL            string `json:"url"`
 }
 
 func (t Todoist) GetProjects() ([]Project, error) {
-	request, err := http.NewRequest(http.MethodGet, PROJECT_URL, nil)
-	if err != nil {
-		return nil, err
-	}
+	return t.GetProjectsWithContext(context.Background())
+}
 
-	request.Header.Set("Authorization", fmt.Sprintf("Bearer %s", t.authToken))
+func (t Todoist) GetProjectsWithContext(ctx context.Context) ([]Project, error) {
+	ctx = withOperation(ctx, "GetProjects", RESOURCE_PROJECT)
 
-	client := &http.Client{
-		Timeout: MAX_TIMEOUT,
+	request, err := t.newRequest(ctx, http.MethodGet, PROJECT_PATH, nil)
+	if err != nil {
+		return nil, err
 	}
 
-	response, err := client.Do(request)
+	response, err := t.do(request)
 	if err != nil {
 		return nil, err
 	}
 	defer response.Body.Close()
 
 	if response.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("HTTP request failed with status code: %d", response.StatusCode)
+		return nil, newAPIError(response)
 	}
 
 	data, err := io.ReadAll(response.Body)
@@ -64,31 +70,85 @@ func (t Todoist) GetProjects() ([]Project, error) {
 }
 
 func (t Todoist) GetProject(id string) (Project, error) {
+	return t.GetProjectWithContext(context.Background(), id)
+}
+
+func (t Todoist) GetProjectWithContext(ctx context.Context, id string) (Project, error) {
+	ctx = withOperation(ctx, "GetProject", RESOURCE_PROJECT)
+
 	if id == "" {
 		return Project{}, errors.New("ID is required")
 	}
 
-	url := fmt.Sprintf("%s/%s", PROJECT_URL, id)
+	path := fmt.Sprintf("%s/%s", PROJECT_PATH, id)
+
+	request, err := t.newRequest(ctx, http.MethodGet, path, nil)
+	if err != nil {
+		return Project{}, err
+	}
+
+	response, err := t.do(request)
+	if err != nil {
+		return Project{}, err
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode != http.StatusOK {
+		return Project{}, newAPIError(response)
+	}
 
-	request, err := http.NewRequest(http.MethodGet, url, nil)
+	data, err := io.ReadAll(response.Body)
 	if err != nil {
 		return Project{}, err
 	}
 
-	client := &http.Client{
-		Timeout: MAX_TIMEOUT,
+	var project Project
+	if err := json.Unmarshal(data, &project); err != nil {
+		return Project{}, err
+	}
+
+	return project, nil
+}
+
+type AddProjectArgs struct {
+	Name       string `json:"name"`
+	ParentID   string `json:"parent_id,omitempty"`
+	Color      string `json:"color,omitempty"`
+	IsFavorite bool   `json:"is_favorite,omitempty"`
+	ViewStyle  string `json:"view_style,omitempty"`
+}
+
+func (t Todoist) AddProject(args AddProjectArgs) (Project, error) {
+	return t.AddProjectWithContext(context.Background(), args)
+}
+
+func (t Todoist) AddProjectWithContext(ctx context.Context, args AddProjectArgs) (Project, error) {
+	ctx = withOperation(ctx, "AddProject", RESOURCE_PROJECT)
+
+	if args.Name == "" {
+		return Project{}, errors.New("`name` is required")
 	}
 
-	request.Header.Set("Authorization", fmt.Sprintf("Bearer %s", t.authToken))
+	bodyRequest, err := json.Marshal(args)
+	if err != nil {
+		return Project{}, err
+	}
 
-	response, err := client.Do(request)
+	request, err := t.newRequest(ctx, http.MethodPost, PROJECT_PATH, bytes.NewReader(bodyRequest))
+	if err != nil {
+		return Project{}, err
+	}
+
+	request.Header.Set("Content-Type", "application/json")
+
+	response, err := t.do(request)
 	if err != nil {
 		return Project{}, err
 	}
 	defer response.Body.Close()
 
 	if response.StatusCode != http.StatusOK {
-		return Project{}, fmt.Errorf("HTTP request failed with status code: %d", response.StatusCode)
+		return Project{}, newAPIError(response)
 	}
 
 	data, err := io.ReadAll(response.Body)
@@ -112,38 +172,38 @@ type UpdateProjectArgs struct {
 }
 
 func (t Todoist) UpdateProject(args UpdateProjectArgs, id string) (Project, error) {
+	return t.UpdateProjectWithContext(context.Background(), args, id)
+}
+
+func (t Todoist) UpdateProjectWithContext(ctx context.Context, args UpdateProjectArgs, id string) (Project, error) {
+	ctx = withOperation(ctx, "UpdateProject", RESOURCE_PROJECT)
+
 	if id == "" {
 		return Project{}, errors.New("ID is required")
 	}
 
-	url := fmt.Sprintf("%s/%s", PROJECT_URL, id)
+	path := fmt.Sprintf("%s/%s", PROJECT_PATH, id)
 
 	bodyRequest, err := json.Marshal(args)
 	if err != nil {
 		return Project{}, err
 	}
 
-	request, err := http.NewRequest(http.MethodPost, url, bytes.NewReader(bodyRequest))
+	request, err := t.newRequest(ctx, http.MethodPost, path, bytes.NewReader(bodyRequest))
 	if err != nil {
 		return Project{}, err
 	}
 
-	request.Header.Set("Authorization", fmt.Sprintf("Bearer %s", t.authToken))
 	request.Header.Set("Content-Type", "application/json")
-	request.Header.Set("X-Request-Id", pkg.NewUUID())
 
-	client := &http.Client{
-		Timeout: MAX_TIMEOUT,
-	}
-
-	response, err := client.Do(request)
+	response, err := t.do(request)
 	if err != nil {
 		return Project{}, err
 	}
 	defer response.Body.Close()
 
 	if response.StatusCode != http.StatusOK {
-		return Project{}, fmt.Errorf("HTTP request failed with status code: %d", response.StatusCode)
+		return Project{}, newAPIError(response)
 	}
 
 	data, err := io.ReadAll(response.Body)
@@ -160,36 +220,88 @@ func (t Todoist) UpdateProject(args UpdateProjectArgs, id string) (Project, erro
 }
 
 func (t Todoist) DeleteProject(id string) error {
+	return t.DeleteProjectWithContext(context.Background(), id)
+}
+
+func (t Todoist) DeleteProjectWithContext(ctx context.Context, id string) error {
+	ctx = withOperation(ctx, "DeleteProject", RESOURCE_PROJECT)
+
 	if id == "" {
 		return errors.New("ID is required")
 	}
 
-	url := fmt.Sprintf("%s/%s", PROJECT_URL, id)
-
-	client := &http.Client{
-		Timeout: MAX_TIMEOUT,
-	}
+	path := fmt.Sprintf("%s/%s", PROJECT_PATH, id)
 
-	request, err := http.NewRequest(http.MethodDelete, url, nil)
+	request, err := t.newRequest(ctx, http.MethodDelete, path, nil)
 	if err != nil {
 		return err
 	}
 
-	request.Header.Set("Authorization", fmt.Sprintf("Bearer %s", t.authToken))
-
-	response, err := client.Do(request)
+	response, err := t.do(request)
 	if err != nil {
 		return err
 	}
 	defer response.Body.Close()
 
 	if response.StatusCode != http.StatusNoContent {
-		return fmt.Errorf("HTTP request failed with status code: %d", response.StatusCode)
+		return newAPIError(response)
 	}
 
 	return nil
 }
 
+// ArchiveProject archives a project, along with its subprojects. The REST API
+// has no endpoint for it, so it goes through the Sync API.
+func (t Todoist) ArchiveProject(id string) error {
+	return t.ArchiveProjectWithContext(context.Background(), id)
+}
+
+func (t Todoist) ArchiveProjectWithContext(ctx context.Context, id string) error {
+	ctx = withOperation(ctx, "ArchiveProject", RESOURCE_PROJECT)
+
+	return t.setProjectArchived(ctx, id, "project_archive")
+}
+
+// UnarchiveProject restores an archived project.
+func (t Todoist) UnarchiveProject(id string) error {
+	return t.UnarchiveProjectWithContext(context.Background(), id)
+}
+
+func (t Todoist) UnarchiveProjectWithContext(ctx context.Context, id string) error {
+	ctx = withOperation(ctx, "UnarchiveProject", RESOURCE_PROJECT)
+
+	return t.setProjectArchived(ctx, id, "project_unarchive")
+}
+
+func (t Todoist) setProjectArchived(ctx context.Context, id string, commandType string) error {
+	if id == "" {
+		return errors.New("ID is required")
+	}
+
+	return t.runCommand(ctx, newSyncCommand(commandType, map[string]any{"id": id}))
+}
+
+// GetArchivedProjects lists archived projects, using the Sync API.
+func (t Todoist) GetArchivedProjects() ([]Project, error) {
+	return t.GetArchivedProjectsWithContext(context.Background())
+}
+
+func (t Todoist) GetArchivedProjectsWithContext(ctx context.Context) ([]Project, error) {
+	ctx = withOperation(ctx, "GetArchivedProjects", RESOURCE_PROJECT)
+
+	var archived []syncProject
+	if err := t.postSync(ctx, ARCHIVED_PROJECTS_PATH, url.Values{}, &archived); err != nil {
+		return nil, err
+	}
+
+	projects := make([]Project, len(archived))
+	for i, project := range archived {
+		projects[i] = project.toProject()
+	}
+
+	return projects, nil
+}
+
 type GetAllCollaboratorsOutput struct {
 	ID    string `json:"id"`
 	Name  string `json:"name"`
@@ -197,31 +309,31 @@ type GetAllCollaboratorsOutput struct {
 }
 
 func (t Todoist) GetAllCollaborators(id string) ([]GetAllCollaboratorsOutput, error) {
+	return t.GetAllCollaboratorsWithContext(context.Background(), id)
+}
+
+func (t Todoist) GetAllCollaboratorsWithContext(ctx context.Context, id string) ([]GetAllCollaboratorsOutput, error) {
+	ctx = withOperation(ctx, "GetAllCollaborators", RESOURCE_PROJECT)
+
 	if id == "" {
 		return nil, errors.New("ID is required")
 	}
 
-	url := fmt.Sprintf("%s/%s/collaborators", PROJECT_URL, id)
+	path := fmt.Sprintf("%s/%s/collaborators", PROJECT_PATH, id)
 
-	request, err := http.NewRequest(http.MethodGet, url, nil)
+	request, err := t.newRequest(ctx, http.MethodGet, path, nil)
 	if err != nil {
 		return nil, err
 	}
 
-	request.Header.Set("Authorization", fmt.Sprintf("Bearer %s", t.authToken))
-
-	client := &http.Client{
-		Timeout: MAX_TIMEOUT,
-	}
-
-	response, err := client.Do(request)
+	response, err := t.do(request)
 	if err != nil {
 		return nil, err
 	}
 	defer response.Body.Close()
 
 	if response.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("HTTP request failed with status code: %d", response.StatusCode)
+		return nil, newAPIError(response)
 	}
 
 	data, err := io.ReadAll(response.Body)
@@ -0,0 +1,58 @@
+package todoist
+
+import (
+	"fmt"
+	"net/http"
+	"reflect"
+	"testing"
+)
+
+func TestArchiveProjectCommands(t *testing.T) {
+	server := newTestServer(t, nil)
+	client := server.client()
+
+	if err := client.ArchiveProject("2203306141"); err != nil {
+		t.Fatal(err)
+	}
+	if err := client.UnarchiveProject("2203306141"); err != nil {
+		t.Fatal(err)
+	}
+
+	requests := server.Requests()
+	if len(requests) != 2 {
+		t.Fatalf("sent %d requests, want 2", len(requests))
+	}
+
+	for i, want := range []string{"project_archive", "project_unarchive"} {
+		if requests[i].Method != http.MethodPost || requests[i].Path != SYNC_PATH {
+			t.Errorf("sent %s %s, want POST %s", requests[i].Method, requests[i].Path, SYNC_PATH)
+		}
+
+		commands := requests[i].commands(t)
+		if len(commands) != 1 || commands[0].Type != want {
+			t.Fatalf("sent commands %+v, want one %s", commands, want)
+		}
+		if args := map[string]any{"id": "2203306141"}; !reflect.DeepEqual(commands[0].Args, args) {
+			t.Errorf("sent args %v, want %v", commands[0].Args, args)
+		}
+	}
+}
+
+func TestGetArchivedProjects(t *testing.T) {
+	server := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `[{"id":"2203306141","name":"Old","child_order":300,"is_archived":true}]`)
+	})
+
+	projects, err := server.client().GetArchivedProjects()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if path := server.Requests()[0].Path; path != ARCHIVED_PROJECTS_PATH {
+		t.Errorf("sent request to %s, want %s", path, ARCHIVED_PROJECTS_PATH)
+	}
+
+	if len(projects) != 1 || projects[0].ID != "2203306141" || !projects[0].IsArchived || projects[0].Order != 300 {
+		t.Errorf("got %+v", projects)
+	}
+}
@@ -0,0 +1,147 @@
+package todoist
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// RateLimiterConfig describes a token bucket refilled with Limit tokens every
+// Interval. Todoist documents a quota of 1000 requests per 15 minutes per user.
+// Limit and Interval must both be positive.
+type RateLimiterConfig struct {
+	Limit    int
+	Interval time.Duration
+	// Burst is the bucket size. It defaults to Limit.
+	Burst int
+	// FailFast makes calls fail with a *RateLimitError instead of blocking
+	// until a token is available.
+	FailFast bool
+}
+
+type RateLimiterStats struct {
+	Requests  int64
+	Waited    int64
+	Rejected  int64
+	TotalWait time.Duration
+	MaxWait   time.Duration
+}
+
+// RateLimiter is a client-side token bucket. It is safe for concurrent use
+// and may be shared by every client acting on behalf of the same user.
+type RateLimiter struct {
+	mu       sync.Mutex
+	rate     float64
+	burst    float64
+	tokens   float64
+	last     time.Time
+	failFast bool
+	stats    RateLimiterStats
+}
+
+// NewRateLimiter returns a full bucket. It fails if config.Limit or
+// config.Interval is not positive, since such a bucket would never refill.
+func NewRateLimiter(config RateLimiterConfig) (*RateLimiter, error) {
+	if config.Limit <= 0 || config.Interval <= 0 {
+		return nil, fmt.Errorf("rate limiter needs a positive limit and interval, got %d per %s", config.Limit, config.Interval)
+	}
+
+	burst := config.Burst
+	if burst <= 0 {
+		burst = config.Limit
+	}
+
+	return &RateLimiter{
+		rate:     float64(config.Limit) / config.Interval.Seconds(),
+		burst:    float64(burst),
+		tokens:   float64(burst),
+		last:     time.Now(),
+		failFast: config.FailFast,
+	}, nil
+}
+
+// WithRateLimiter makes every request, including retries, take a token from
+// limiter before being sent.
+func WithRateLimiter(limiter *RateLimiter) Option {
+	return func(t *Todoist) {
+		t.rateLimiter = limiter
+	}
+}
+
+// RateLimitError is returned when a fail-fast RateLimiter has no token left.
+// It matches ErrRateLimited through errors.Is.
+type RateLimitError struct {
+	Wait time.Duration
+}
+
+func (e *RateLimitError) Error() string {
+	return fmt.Sprintf("client-side rate limit exceeded, next request allowed in %s", e.Wait)
+}
+
+func (e *RateLimitError) Is(target error) bool {
+	return target == ErrRateLimited
+}
+
+// Wait takes a token from the bucket, blocking until one is available or ctx
+// is done.
+func (l *RateLimiter) Wait(ctx context.Context) error {
+	l.mu.Lock()
+
+	now := time.Now()
+	l.refill(now)
+	l.stats.Requests++
+
+	if l.tokens >= 1 {
+		l.tokens--
+		l.mu.Unlock()
+		return nil
+	}
+
+	wait := time.Duration((1 - l.tokens) / l.rate * float64(time.Second))
+
+	if l.failFast {
+		l.stats.Rejected++
+		l.mu.Unlock()
+		return &RateLimitError{Wait: wait}
+	}
+
+	// The token is reserved now so concurrent callers queue up behind it.
+	l.tokens--
+	l.stats.Waited++
+	l.stats.TotalWait += wait
+	if wait > l.stats.MaxWait {
+		l.stats.MaxWait = wait
+	}
+	l.mu.Unlock()
+
+	timer := time.NewTimer(wait)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		l.mu.Lock()
+		l.tokens++
+		l.mu.Unlock()
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+func (l *RateLimiter) Stats() RateLimiterStats {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	return l.stats
+}
+
+func (l *RateLimiter) refill(now time.Time) {
+	elapsed := now.Sub(l.last).Seconds()
+	l.last = now
+
+	l.tokens += elapsed * l.rate
+	if l.tokens > l.burst {
+		l.tokens = l.burst
+	}
+}
@@ -0,0 +1,124 @@
+package todoist
+
+import (
+	"context"
+	"errors"
+	"math"
+	"sync"
+	"testing"
+	"time"
+)
+
+func newRateLimiter(t *testing.T, config RateLimiterConfig) *RateLimiter {
+	t.Helper()
+
+	limiter, err := NewRateLimiter(config)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return limiter
+}
+
+func TestNewRateLimiterInvalid(t *testing.T) {
+	for _, config := range []RateLimiterConfig{
+		{Limit: 10},
+		{Interval: time.Second},
+		{Limit: -1, Interval: time.Second},
+		{Limit: 10, Interval: -time.Second},
+	} {
+		if limiter, err := NewRateLimiter(config); err == nil || limiter != nil {
+			t.Errorf("NewRateLimiter(%+v) = %v, %v, want an error", config, limiter, err)
+		}
+	}
+}
+
+func TestRateLimiterFailFast(t *testing.T) {
+	limiter := newRateLimiter(t, RateLimiterConfig{Limit: 2, Interval: time.Hour, FailFast: true})
+
+	for i := 0; i < 2; i++ {
+		if err := limiter.Wait(context.Background()); err != nil {
+			t.Fatalf("call %d: %v", i+1, err)
+		}
+	}
+
+	err := limiter.Wait(context.Background())
+
+	var rateErr *RateLimitError
+	if !errors.As(err, &rateErr) || !errors.Is(err, ErrRateLimited) {
+		t.Fatalf("err = %v, want a RateLimitError", err)
+	}
+	if rateErr.Wait <= 0 || rateErr.Wait > time.Hour/2 {
+		t.Errorf("Wait = %s, want about %s", rateErr.Wait, time.Hour/2)
+	}
+
+	if stats := limiter.Stats(); stats.Requests != 3 || stats.Rejected != 1 || stats.Waited != 0 {
+		t.Errorf("stats = %+v", stats)
+	}
+}
+
+// TestRateLimiterReservation checks that concurrent callers each reserve a
+// token, so they are released one interval apart instead of all at once.
+func TestRateLimiterReservation(t *testing.T) {
+	const callers = 5
+	limiter := newRateLimiter(t, RateLimiterConfig{Limit: 1, Interval: 20 * time.Millisecond, Burst: 1})
+
+	start := time.Now()
+
+	var wg sync.WaitGroup
+	for i := 0; i < callers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if err := limiter.Wait(context.Background()); err != nil {
+				t.Error(err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if elapsed := time.Since(start); elapsed < 70*time.Millisecond {
+		t.Errorf("%d callers took %s, want about %s", callers, elapsed, 80*time.Millisecond)
+	}
+
+	stats := limiter.Stats()
+	if stats.Requests != callers || stats.Waited != callers-1 || stats.Rejected != 0 {
+		t.Errorf("stats = %+v", stats)
+	}
+	if stats.MaxWait < 70*time.Millisecond {
+		t.Errorf("MaxWait = %s, want the last caller queued behind the others", stats.MaxWait)
+	}
+}
+
+// TestRateLimiterRefund checks that callers giving up return their reserved
+// token, so later callers do not wait for tokens nobody used.
+func TestRateLimiterRefund(t *testing.T) {
+	limiter := newRateLimiter(t, RateLimiterConfig{Limit: 1, Interval: time.Hour})
+
+	if err := limiter.Wait(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if err := limiter.Wait(ctx); !errors.Is(err, context.DeadlineExceeded) {
+				t.Errorf("err = %v, want %v", err, context.DeadlineExceeded)
+			}
+		}()
+	}
+	wg.Wait()
+
+	limiter.mu.Lock()
+	tokens := limiter.tokens
+	limiter.mu.Unlock()
+
+	if math.Abs(tokens) > 0.01 {
+		t.Errorf("tokens = %f after every waiter gave up, want 0", tokens)
+	}
+}
@@ -0,0 +1,126 @@
+package replica
+
+import (
+	todoist "github.com/felipeornelis/todoist-go-client"
+)
+
+// Tasks returns the active tasks matching args, like GetTasks does. Filter
+// queries need Todoist's parser, so args.Filter and args.Lang are ignored.
+func (r *Replica) Tasks(args todoist.GetTasksArgs) []todoist.Task {
+	var ids map[string]bool
+	if len(args.IDs) > 0 {
+		ids = map[string]bool{}
+		for _, id := range args.IDs {
+			ids[id] = true
+		}
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	return sortedValues(r.state.Tasks, func(task todoist.Task) bool {
+		switch {
+		case task.IsCompleted:
+		case ids != nil && !ids[task.ID]:
+		case args.ProjectID != "" && task.ProjectID != args.ProjectID:
+		case args.SectionID != "" && task.SectionID != args.SectionID:
+		case args.Label != "" && !hasLabel(task, args.Label):
+		default:
+			return true
+		}
+		return false
+	})
+}
+
+func (r *Replica) Task(id string) (todoist.Task, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	task, ok := r.state.Tasks[id]
+	return task, ok
+}
+
+func (r *Replica) Projects() []todoist.Project {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	return sortedValues(r.state.Projects, nil)
+}
+
+func (r *Replica) Project(id string) (todoist.Project, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	project, ok := r.state.Projects[id]
+	return project, ok
+}
+
+// Sections returns the sections of the project, or every section when
+// projectID is empty.
+func (r *Replica) Sections(projectID string) []todoist.Section {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	return sortedValues(r.state.Sections, func(section todoist.Section) bool {
+		return projectID == "" || section.ProjectID == projectID
+	})
+}
+
+func (r *Replica) Section(id string) (todoist.Section, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	section, ok := r.state.Sections[id]
+	return section, ok
+}
+
+func (r *Replica) Labels() []todoist.Label {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	return sortedValues(r.state.Labels, nil)
+}
+
+func (r *Replica) Label(id string) (todoist.Label, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	label, ok := r.state.Labels[id]
+	return label, ok
+}
+
+// Comments returns the comments of a task or a project, like GetComments
+// does.
+func (r *Replica) Comments(args todoist.GetCommentsArgs) []todoist.Comment {
+	if args.TaskID == "" && args.ProjectID == "" {
+		return nil
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	return sortedValues(r.state.Comments, func(comment todoist.Comment) bool {
+		if args.ProjectID != "" {
+			return comment.ProjectID == args.ProjectID
+		}
+		return comment.TaskID == args.TaskID
+	})
+}
+
+func (r *Replica) Comment(id string) (todoist.Comment, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	comment, ok := r.state.Comments[id]
+	return comment, ok
+}
+
+func hasLabel(task todoist.Task, name string) bool {
+	for _, label := range task.Labels {
+		if label == name {
+			return true
+		}
+	}
+
+	return false
+}
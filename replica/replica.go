@@ -0,0 +1,237 @@
+// Package replica keeps a local, file-backed copy of a Todoist account up to
+// date through incremental syncs, so read-heavy services can query it
+// instead of calling the API on every request.
+package replica
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"os"
+	"sort"
+	"sync"
+	"time"
+
+	todoist "github.com/felipeornelis/todoist-go-client"
+	"github.com/felipeornelis/todoist-go-client/pkg"
+)
+
+var resourceTypes = []string{"items", "projects", "sections", "labels", "notes", "project_notes"}
+
+// snapshot is the content of the replica file.
+type snapshot struct {
+	SyncToken string                     `json:"sync_token"`
+	SyncedAt  time.Time                  `json:"synced_at"`
+	Tasks     map[string]todoist.Task    `json:"tasks"`
+	Projects  map[string]todoist.Project `json:"projects"`
+	Sections  map[string]todoist.Section `json:"sections"`
+	Labels    map[string]todoist.Label   `json:"labels"`
+	Comments  map[string]todoist.Comment `json:"comments"`
+}
+
+func newSnapshot() snapshot {
+	return snapshot{
+		Tasks:    map[string]todoist.Task{},
+		Projects: map[string]todoist.Project{},
+		Sections: map[string]todoist.Section{},
+		Labels:   map[string]todoist.Label{},
+		Comments: map[string]todoist.Comment{},
+	}
+}
+
+// Replica is a local copy of the tasks, projects, sections, labels and
+// comments of an account. It is safe for concurrent use.
+type Replica struct {
+	path   string
+	syncer *todoist.Syncer
+
+	// refreshMu serialises refreshes, so changes are applied in the order
+	// they were synced.
+	refreshMu sync.Mutex
+
+	mu    sync.RWMutex
+	state snapshot
+
+	subscribersMu sync.Mutex
+	subscribers   map[int]func(todoist.SyncResult)
+	nextID        int
+}
+
+// Open loads the replica stored at path, if any, and resumes syncing from
+// its sync token. Call Refresh to bring it up to date.
+func Open(path string, client todoist.Todoist) (*Replica, error) {
+	state := newSnapshot()
+
+	data, err := os.ReadFile(path)
+	switch {
+	case errors.Is(err, os.ErrNotExist):
+	case err != nil:
+		return nil, err
+	default:
+		if err := json.Unmarshal(data, &state); err != nil {
+			return nil, err
+		}
+	}
+
+	return &Replica{
+		path:        path,
+		syncer:      client.NewSyncer(state.SyncToken, resourceTypes...),
+		state:       state,
+		subscribers: map[int]func(todoist.SyncResult){},
+	}, nil
+}
+
+// Subscribe registers fn to be called with the changes of every Refresh
+// that updated the replica. The returned function unregisters it.
+func (r *Replica) Subscribe(fn func(todoist.SyncResult)) func() {
+	r.subscribersMu.Lock()
+	defer r.subscribersMu.Unlock()
+
+	id := r.nextID
+	r.nextID++
+	r.subscribers[id] = fn
+
+	return func() {
+		r.subscribersMu.Lock()
+		defer r.subscribersMu.Unlock()
+
+		delete(r.subscribers, id)
+	}
+}
+
+// Refresh fetches what changed since the last sync, applies it, persists
+// the replica and notifies subscribers.
+func (r *Replica) Refresh(ctx context.Context) (todoist.SyncResult, error) {
+	r.refreshMu.Lock()
+	defer r.refreshMu.Unlock()
+
+	result, err := r.syncer.Sync(ctx)
+	if err != nil {
+		return todoist.SyncResult{}, err
+	}
+
+	r.mu.Lock()
+	r.apply(result)
+	data, err := json.Marshal(r.state)
+	r.mu.Unlock()
+	if err != nil {
+		return result, err
+	}
+
+	if err := pkg.WriteFileAtomic(r.path, data); err != nil {
+		return result, err
+	}
+
+	if result.FullSync || result.HasChanges() {
+		r.notify(result)
+	}
+
+	return result, nil
+}
+
+// Run refreshes the replica every interval until ctx is done. Refresh
+// errors are passed to onError, when given, without stopping the loop.
+func (r *Replica) Run(ctx context.Context, interval time.Duration, onError func(error)) error {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		if _, err := r.Refresh(ctx); err != nil && onError != nil && ctx.Err() == nil {
+			onError(err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+// SyncedAt returns when the replica was last refreshed.
+func (r *Replica) SyncedAt() time.Time {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	return r.state.SyncedAt
+}
+
+// apply must be called with r.mu held.
+func (r *Replica) apply(result todoist.SyncResult) {
+	if result.FullSync {
+		r.state = newSnapshot()
+	}
+
+	r.state.SyncToken = result.SyncToken
+	r.state.SyncedAt = time.Now()
+
+	for _, task := range result.Tasks {
+		r.state.Tasks[task.ID] = task
+	}
+	for _, project := range result.Projects {
+		r.state.Projects[project.ID] = project
+	}
+	for _, section := range result.Sections {
+		r.state.Sections[section.ID] = section
+	}
+	for _, label := range result.Labels {
+		r.state.Labels[label.ID] = label
+	}
+	for _, comment := range result.Comments {
+		r.state.Comments[comment.ID] = comment
+	}
+
+	for _, id := range result.Deleted.Tasks {
+		delete(r.state.Tasks, id)
+	}
+	for _, id := range result.Deleted.Projects {
+		delete(r.state.Projects, id)
+	}
+	for _, id := range result.Deleted.Sections {
+		delete(r.state.Sections, id)
+	}
+	for _, id := range result.Deleted.Labels {
+		delete(r.state.Labels, id)
+	}
+	for _, id := range result.Deleted.Comments {
+		delete(r.state.Comments, id)
+	}
+}
+
+func (r *Replica) notify(result todoist.SyncResult) {
+	r.subscribersMu.Lock()
+	subscribers := make([]func(todoist.SyncResult), 0, len(r.subscribers))
+	for _, fn := range r.subscribers {
+		subscribers = append(subscribers, fn)
+	}
+	r.subscribersMu.Unlock()
+
+	for _, fn := range subscribers {
+		fn(result)
+	}
+}
+
+// sortedValues returns the values of a resource map ordered by ID, keeping
+// the creation order of Todoist's numeric IDs.
+func sortedValues[T any](resources map[string]T, keep func(T) bool) []T {
+	ids := make([]string, 0, len(resources))
+	for id, resource := range resources {
+		if keep == nil || keep(resource) {
+			ids = append(ids, id)
+		}
+	}
+
+	sort.Slice(ids, func(i, j int) bool {
+		if len(ids[i]) != len(ids[j]) {
+			return len(ids[i]) < len(ids[j])
+		}
+		return ids[i] < ids[j]
+	})
+
+	values := make([]T, len(ids))
+	for i, id := range ids {
+		values[i] = resources[id]
+	}
+
+	return values
+}
@@ -0,0 +1,305 @@
+package replica_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"reflect"
+	"sync"
+	"testing"
+
+	todoist "github.com/felipeornelis/todoist-go-client"
+	"github.com/felipeornelis/todoist-go-client/replica"
+)
+
+// syncServer stands in for the Sync API, answering each sync token with a
+// canned response.
+type syncServer struct {
+	*httptest.Server
+
+	mu     sync.Mutex
+	tokens []string
+}
+
+func newSyncServer(t *testing.T, responses map[string]string) *syncServer {
+	t.Helper()
+
+	server := &syncServer{}
+	server.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		token := r.FormValue("sync_token")
+
+		server.mu.Lock()
+		server.tokens = append(server.tokens, token)
+		server.mu.Unlock()
+
+		response, ok := responses[token]
+		if !ok {
+			t.Errorf("unexpected sync token %q", token)
+			http.Error(w, "Invalid sync token", http.StatusBadRequest)
+			return
+		}
+
+		fmt.Fprint(w, response)
+	}))
+	t.Cleanup(server.Close)
+
+	return server
+}
+
+func (s *syncServer) client() todoist.Todoist {
+	return todoist.New("token", todoist.WithSyncURL(s.URL))
+}
+
+// Tokens returns the sync tokens received so far.
+func (s *syncServer) Tokens() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return append([]string(nil), s.tokens...)
+}
+
+func open(t *testing.T, path string, client todoist.Todoist) *replica.Replica {
+	t.Helper()
+
+	r, err := replica.Open(path, client)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return r
+}
+
+func refresh(t *testing.T, r *replica.Replica) todoist.SyncResult {
+	t.Helper()
+
+	result, err := r.Refresh(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return result
+}
+
+func taskIDs(tasks []todoist.Task) []string {
+	ids := []string{}
+	for _, task := range tasks {
+		ids = append(ids, task.ID)
+	}
+
+	return ids
+}
+
+const fullSync = `{
+	"full_sync": true,
+	"sync_token": "token-1",
+	"items": [
+		{"id": "1", "project_id": "100", "section_id": "10", "content": "Passport", "labels": ["travel"]},
+		{"id": "2", "project_id": "100", "content": "Tickets"}
+	],
+	"projects": [{"id": "100", "name": "Trip"}],
+	"sections": [{"id": "10", "project_id": "100", "name": "Packing"}],
+	"labels": [{"id": "1000", "name": "travel"}],
+	"notes": [{"id": "50", "item_id": "1", "project_id": "100", "content": "In the drawer"}],
+	"project_notes": [{"id": "51", "project_id": "100", "content": "Leave at 6"}]
+}`
+
+func TestRefreshResumesAfterReopen(t *testing.T) {
+	server := newSyncServer(t, map[string]string{
+		"*": fullSync,
+		"token-1": `{
+			"full_sync": false,
+			"sync_token": "token-2",
+			"items": [
+				{"id": "1", "project_id": "100", "section_id": "10", "content": "Passport and visa", "labels": ["travel"]},
+				{"id": "3", "project_id": "100", "content": "Sunscreen"}
+			]
+		}`,
+		"token-2": `{"full_sync": false, "sync_token": "token-2"}`,
+	})
+	path := filepath.Join(t.TempDir(), "replica", "account.json")
+
+	r := open(t, path, server.client())
+	if tasks := r.Tasks(todoist.GetTasksArgs{}); len(tasks) != 0 {
+		t.Fatalf("new replica has tasks %+v", tasks)
+	}
+	refresh(t, r)
+
+	// A reopened replica answers from the file before refreshing.
+	r = open(t, path, server.client())
+	if got := taskIDs(r.Tasks(todoist.GetTasksArgs{})); !reflect.DeepEqual(got, []string{"1", "2"}) {
+		t.Errorf("tasks after reopening = %v, want [1 2]", got)
+	}
+	if r.SyncedAt().IsZero() {
+		t.Error("synced at not persisted")
+	}
+	if comments := r.Comments(todoist.GetCommentsArgs{TaskID: "1"}); len(comments) != 1 || comments[0].Content != "In the drawer" {
+		t.Errorf("task comments = %+v", comments)
+	}
+	if comments := r.Comments(todoist.GetCommentsArgs{ProjectID: "100"}); len(comments) != 1 || comments[0].ID != "51" {
+		t.Errorf("project comments = %+v", comments)
+	}
+
+	refresh(t, r)
+	if got := taskIDs(r.Tasks(todoist.GetTasksArgs{})); !reflect.DeepEqual(got, []string{"1", "2", "3"}) {
+		t.Errorf("tasks = %v, want [1 2 3]", got)
+	}
+	if task, _ := r.Task("1"); task.Content != "Passport and visa" {
+		t.Errorf("task = %+v, want the update applied", task)
+	}
+
+	refresh(t, open(t, path, server.client()))
+
+	if want := []string{"*", "token-1", "token-2"}; !reflect.DeepEqual(server.Tokens(), want) {
+		t.Errorf("sync tokens = %v, want %v", server.Tokens(), want)
+	}
+}
+
+func TestRefreshFullSyncReplacesState(t *testing.T) {
+	// Todoist answers an expired token with a new full sync.
+	server := newSyncServer(t, map[string]string{
+		"*": fullSync,
+		"token-1": `{
+			"full_sync": true,
+			"sync_token": "token-2",
+			"items": [{"id": "3", "project_id": "200", "content": "Rent"}],
+			"projects": [{"id": "200", "name": "Home"}]
+		}`,
+	})
+	path := filepath.Join(t.TempDir(), "account.json")
+
+	r := open(t, path, server.client())
+	refresh(t, r)
+	refresh(t, r)
+
+	for _, r := range []*replica.Replica{r, open(t, path, server.client())} {
+		if got := taskIDs(r.Tasks(todoist.GetTasksArgs{})); !reflect.DeepEqual(got, []string{"3"}) {
+			t.Errorf("tasks = %v, want [3]", got)
+		}
+		if projects := r.Projects(); len(projects) != 1 || projects[0].ID != "200" {
+			t.Errorf("projects = %+v", projects)
+		}
+		if len(r.Sections("")) != 0 || len(r.Labels()) != 0 {
+			t.Errorf("sections %+v and labels %+v kept from the previous sync", r.Sections(""), r.Labels())
+		}
+		if _, ok := r.Comment("50"); ok {
+			t.Error("comment kept from the previous sync")
+		}
+	}
+}
+
+func TestRefreshDeletions(t *testing.T) {
+	server := newSyncServer(t, map[string]string{
+		"*": fullSync,
+		"token-1": `{
+			"full_sync": false,
+			"sync_token": "token-2",
+			"items": [{"id": "1", "is_deleted": true}],
+			"sections": [{"id": "10", "project_id": "100", "is_deleted": true}],
+			"labels": [{"id": "1000", "is_deleted": true}],
+			"notes": [{"id": "50", "item_id": "1", "is_deleted": true}],
+			"project_notes": [{"id": "51", "project_id": "100", "is_deleted": true}]
+		}`,
+	})
+	path := filepath.Join(t.TempDir(), "account.json")
+
+	r := open(t, path, server.client())
+	refresh(t, r)
+	refresh(t, r)
+
+	for _, r := range []*replica.Replica{r, open(t, path, server.client())} {
+		if _, ok := r.Task("1"); ok {
+			t.Error("deleted task kept")
+		}
+		if got := taskIDs(r.Tasks(todoist.GetTasksArgs{})); !reflect.DeepEqual(got, []string{"2"}) {
+			t.Errorf("tasks = %v, want [2]", got)
+		}
+		if _, ok := r.Section("10"); ok {
+			t.Error("deleted section kept")
+		}
+		if _, ok := r.Label("1000"); ok {
+			t.Error("deleted label kept")
+		}
+		if len(r.Comments(todoist.GetCommentsArgs{TaskID: "1"}))+len(r.Comments(todoist.GetCommentsArgs{ProjectID: "100"})) != 0 {
+			t.Error("deleted comments kept")
+		}
+		if _, ok := r.Project("100"); !ok {
+			t.Error("project removed without being deleted")
+		}
+	}
+}
+
+func TestSubscribe(t *testing.T) {
+	server := newSyncServer(t, map[string]string{
+		"*":       fullSync,
+		"token-1": `{"full_sync": false, "sync_token": "token-2"}`,
+		"token-2": `{"full_sync": false, "sync_token": "token-3", "items": [{"id": "2", "is_deleted": true}]}`,
+		"token-3": `{"full_sync": false, "sync_token": "token-4", "items": [{"id": "4", "content": "Hat"}]}`,
+	})
+
+	r := open(t, filepath.Join(t.TempDir(), "account.json"), server.client())
+
+	var tokens []string
+	unsubscribe := r.Subscribe(func(result todoist.SyncResult) {
+		tokens = append(tokens, result.SyncToken)
+	})
+
+	refresh(t, r)
+	refresh(t, r)
+	refresh(t, r)
+	unsubscribe()
+	refresh(t, r)
+
+	// The empty sync with token-1 and the sync after unsubscribing are not
+	// reported.
+	if want := []string{"token-1", "token-3"}; !reflect.DeepEqual(tokens, want) {
+		t.Errorf("notified for %v, want %v", tokens, want)
+	}
+}
+
+func TestTasks(t *testing.T) {
+	server := newSyncServer(t, map[string]string{
+		"*": `{
+			"full_sync": true,
+			"sync_token": "token-1",
+			"items": [
+				{"id": "1", "project_id": "100", "section_id": "10", "content": "Passport", "labels": ["travel", "urgent"]},
+				{"id": "2", "project_id": "100", "content": "Tickets", "labels": ["travel"]},
+				{"id": "3", "project_id": "100", "section_id": "10", "content": "Charger", "checked": true, "labels": ["travel"]},
+				{"id": "4", "project_id": "200", "section_id": "20", "content": "Rent", "labels": ["urgent"]},
+				{"id": "12", "project_id": "200", "content": "Plants"}
+			]
+		}`,
+	})
+
+	r := open(t, filepath.Join(t.TempDir(), "account.json"), server.client())
+	refresh(t, r)
+
+	tests := []struct {
+		name string
+		args todoist.GetTasksArgs
+		want []string
+	}{
+		{"active tasks by ID order", todoist.GetTasksArgs{}, []string{"1", "2", "4", "12"}},
+		{"project", todoist.GetTasksArgs{ProjectID: "100"}, []string{"1", "2"}},
+		{"section", todoist.GetTasksArgs{SectionID: "10"}, []string{"1"}},
+		{"label", todoist.GetTasksArgs{Label: "urgent"}, []string{"1", "4"}},
+		{"IDs", todoist.GetTasksArgs{IDs: []string{"12", "3", "2"}}, []string{"2", "12"}},
+		{"combined", todoist.GetTasksArgs{ProjectID: "100", Label: "travel", IDs: []string{"2", "4"}}, []string{"2"}},
+		{"filter ignored", todoist.GetTasksArgs{Filter: "today", ProjectID: "200"}, []string{"4", "12"}},
+		{"no match", todoist.GetTasksArgs{ProjectID: "300"}, []string{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := taskIDs(r.Tasks(tt.args)); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("tasks = %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	if task, ok := r.Task("3"); !ok || !task.IsCompleted {
+		t.Errorf("completed task = %+v, %t, want it kept for Task", task, ok)
+	}
+}
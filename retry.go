@@ -0,0 +1,155 @@
+package todoist
+
+import (
+	"io"
+	"math/rand"
+	"net/http"
+	"time"
+)
+
+// RetryPolicy controls how requests answered with 429 or 5xx, or failed at
+// the network level, are retried. Every attempt reuses the request's
+// X-Request-Id, so Todoist deduplicates retried mutations.
+type RetryPolicy struct {
+	// MaxAttempts is the total number of attempts, including the first one.
+	// Values lower than 2 disable retries.
+	MaxAttempts int
+	// MinBackoff is the delay before the second attempt. It doubles on each
+	// subsequent attempt, up to MaxBackoff. Zero values fall back to the ones
+	// of DefaultRetryPolicy.
+	MinBackoff time.Duration
+	// MaxBackoff also bounds the delay asked for by a Retry-After header:
+	// responses asking to wait longer are returned without retrying, so a
+	// server cannot block a call for longer.
+	MaxBackoff time.Duration
+	// DisableJitter turns off the randomisation of backoff delays.
+	DisableJitter bool
+}
+
+var DefaultRetryPolicy = RetryPolicy{
+	MaxAttempts: 4,
+	MinBackoff:  500 * time.Millisecond,
+	MaxBackoff:  30 * time.Second,
+}
+
+// WithRetryPolicy enables automatic retries. Without it every request is
+// attempted once.
+func WithRetryPolicy(policy RetryPolicy) Option {
+	return func(t *Todoist) {
+		t.retryPolicy = policy
+	}
+}
+
+func (p RetryPolicy) attempts() int {
+	if p.MaxAttempts < 1 {
+		return 1
+	}
+	return p.MaxAttempts
+}
+
+// backoff returns how long to wait after the given failed attempt. A
+// Retry-After header sent by the server takes precedence; ok is false when it
+// asks to wait longer than MaxBackoff, in which case the call is not retried.
+func (p RetryPolicy) backoff(attempt int, response *http.Response) (wait time.Duration, ok bool) {
+	minBackoff, maxBackoff := p.MinBackoff, p.MaxBackoff
+	if minBackoff <= 0 {
+		minBackoff = DefaultRetryPolicy.MinBackoff
+	}
+	if maxBackoff <= 0 {
+		maxBackoff = DefaultRetryPolicy.MaxBackoff
+	}
+	if maxBackoff < minBackoff {
+		maxBackoff = minBackoff
+	}
+
+	if response != nil {
+		if wait := parseRetryAfter(response.Header.Get("Retry-After")); wait > 0 {
+			return wait, wait <= maxBackoff
+		}
+	}
+
+	wait = minBackoff
+	for i := 1; i < attempt && wait < maxBackoff; i++ {
+		wait *= 2
+	}
+
+	wait = min(wait, maxBackoff)
+
+	if !p.DisableJitter && wait > 1 {
+		wait = wait/2 + time.Duration(rand.Int63n(int64(wait/2)+1))
+	}
+
+	return wait, true
+}
+
+func shouldRetry(response *http.Response, err error) bool {
+	if err != nil {
+		return true
+	}
+
+	return response.StatusCode == http.StatusTooManyRequests || response.StatusCode >= http.StatusInternalServerError
+}
+
+// do sends the request, retrying it according to the client's RetryPolicy.
+func (t Todoist) do(request *http.Request) (response *http.Response, err error) {
+	request, endCall := t.startCall(request)
+	attempt := 0
+	defer func() {
+		endCall(response, err, attempt)
+	}()
+
+	ctx := request.Context()
+	attempts := t.retryPolicy.attempts()
+	transport := t.roundTripper()
+
+	for attempt = 1; ; attempt++ {
+		if t.rateLimiter != nil {
+			if err := t.rateLimiter.Wait(ctx); err != nil {
+				return nil, err
+			}
+		}
+
+		// The token is asked for on every attempt, so one rotated during a
+		// backoff is picked up.
+		token, err := t.token(ctx)
+		if err != nil {
+			return nil, err
+		}
+		request.Header.Set("Authorization", "Bearer "+token)
+
+		start := time.Now()
+		response, err := transport.RoundTrip(request)
+		latency := time.Since(start)
+
+		wait, ok := t.retryPolicy.backoff(attempt, response)
+
+		if !ok || attempt >= attempts || ctx.Err() != nil || !shouldRetry(response, err) ||
+			(request.Body != nil && request.GetBody == nil) {
+			t.logAttempt(request, response, err, attempt, latency, false)
+			return response, err
+		}
+
+		t.logAttempt(request, response, err, attempt, latency, true)
+
+		if response != nil {
+			io.Copy(io.Discard, response.Body)
+			response.Body.Close()
+		}
+
+		timer := time.NewTimer(wait)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return nil, ctx.Err()
+		case <-timer.C:
+		}
+
+		if request.GetBody != nil {
+			body, err := request.GetBody()
+			if err != nil {
+				return nil, err
+			}
+			request.Body = body
+		}
+	}
+}
@@ -0,0 +1,138 @@
+package todoist
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// failFirst answers the first n requests with status and the others with
+// a task.
+func failFirst(n int32, status int, header http.Header) http.HandlerFunc {
+	var calls atomic.Int32
+	return func(w http.ResponseWriter, r *http.Request) {
+		if calls.Add(1) <= n {
+			for key, values := range header {
+				w.Header()[key] = values
+			}
+			w.WriteHeader(status)
+			return
+		}
+		fmt.Fprint(w, `{"id": "1", "content": "Buy milk"}`)
+	}
+}
+
+var fastRetries = RetryPolicy{
+	MaxAttempts:   4,
+	MinBackoff:    time.Millisecond,
+	MaxBackoff:    5 * time.Millisecond,
+	DisableJitter: true,
+}
+
+func TestRetry(t *testing.T) {
+	for _, status := range []int{http.StatusServiceUnavailable, http.StatusTooManyRequests} {
+		t.Run(http.StatusText(status), func(t *testing.T) {
+			server := newTestServer(t, failFirst(2, status, nil))
+
+			task, err := server.client(WithRetryPolicy(fastRetries)).AddTask(AddTaskArgs{Content: "Buy milk"})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if task.ID != "1" {
+				t.Errorf("task ID = %q, want 1", task.ID)
+			}
+
+			requests := server.Requests()
+			if len(requests) != 3 {
+				t.Fatalf("got %d attempts, want 3", len(requests))
+			}
+
+			for i, request := range requests {
+				if got, want := request.Header.Get("X-Request-Id"), requests[0].Header.Get("X-Request-Id"); got != want || got == "" {
+					t.Errorf("attempt %d X-Request-Id = %q, want %q", i+1, got, want)
+				}
+				if request.Body != requests[0].Body || request.Body == "" {
+					t.Errorf("attempt %d body = %q, want %q", i+1, request.Body, requests[0].Body)
+				}
+			}
+		})
+	}
+}
+
+func TestRetryGivesUp(t *testing.T) {
+	server := newTestServer(t, failFirst(10, http.StatusBadGateway, nil))
+
+	_, err := server.client(WithRetryPolicy(fastRetries)).GetTask("1")
+
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusBadGateway {
+		t.Fatalf("err = %v, want a 502 APIError", err)
+	}
+	if got := len(server.Requests()); got != fastRetries.MaxAttempts {
+		t.Errorf("got %d attempts, want %d", got, fastRetries.MaxAttempts)
+	}
+}
+
+func TestRetryNotRetried(t *testing.T) {
+	server := newTestServer(t, failFirst(1, http.StatusBadRequest, nil))
+
+	if _, err := server.client(WithRetryPolicy(fastRetries)).GetTask("1"); err == nil {
+		t.Fatal("want an error")
+	}
+	if got := len(server.Requests()); got != 1 {
+		t.Errorf("got %d attempts, want 1", got)
+	}
+}
+
+func TestRetryAfterTooLong(t *testing.T) {
+	server := newTestServer(t, failFirst(1, http.StatusTooManyRequests, http.Header{"Retry-After": {"3600"}}))
+
+	start := time.Now()
+	_, err := server.client(WithRetryPolicy(fastRetries)).GetTask("1")
+
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusTooManyRequests || apiErr.RetryAfter != time.Hour {
+		t.Fatalf("err = %v, want a 429 APIError asking to retry after 1h", err)
+	}
+	if got := len(server.Requests()); got != 1 {
+		t.Errorf("got %d attempts, want 1", got)
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("call took %s, want it to return without waiting", elapsed)
+	}
+}
+
+func TestRetryBackoff(t *testing.T) {
+	retryAfter := func(value string) *http.Response {
+		return &http.Response{Header: http.Header{"Retry-After": {value}}}
+	}
+
+	tests := []struct {
+		name     string
+		policy   RetryPolicy
+		attempt  int
+		response *http.Response
+		want     time.Duration
+		wantOK   bool
+	}{
+		{"first", RetryPolicy{MinBackoff: time.Second, MaxBackoff: time.Minute, DisableJitter: true}, 1, nil, time.Second, true},
+		{"doubles", RetryPolicy{MinBackoff: time.Second, MaxBackoff: time.Minute, DisableJitter: true}, 3, nil, 4 * time.Second, true},
+		{"capped", RetryPolicy{MinBackoff: time.Second, MaxBackoff: 5 * time.Second, DisableJitter: true}, 10, nil, 5 * time.Second, true},
+		{"zero min", RetryPolicy{MaxAttempts: 5, DisableJitter: true}, 1, nil, DefaultRetryPolicy.MinBackoff, true},
+		{"zero max", RetryPolicy{MaxAttempts: 5, DisableJitter: true}, 20, nil, DefaultRetryPolicy.MaxBackoff, true},
+		{"retry after", RetryPolicy{MinBackoff: time.Second, MaxBackoff: time.Minute}, 1, retryAfter("10"), 10 * time.Second, true},
+		{"retry after too long", RetryPolicy{MinBackoff: time.Second, MaxBackoff: time.Minute}, 1, retryAfter("3600"), time.Hour, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := tt.policy.backoff(tt.attempt, tt.response)
+			if got != tt.want || ok != tt.wantOK {
+				t.Errorf("backoff = %s, %t, want %s, %t", got, ok, tt.want, tt.wantOK)
+			}
+		})
+	}
+}
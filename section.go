@@ -2,44 +2,52 @@ package todoist
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
 	"io"
 	"net/http"
+	"net/url"
 )
 
-const SECTION_URL = BASE_URL + "/sections"
+const (
+	SECTION_PATH = "/sections"
+	SECTION_URL  = BASE_URL + SECTION_PATH
+)
 
 type Section struct {
 	ID        string `json:"id"`
 	ProjectID string `json:"project_id"`
-	Order     uint8  `json:"order"`
+	Order     int    `json:"order"`
 	Name      string `json:"name"`
 }
 
 func (t Todoist) GetSections(id string) ([]Section, error) {
-	url := fmt.Sprintf("%s?project_id=%s", PROJECT_URL, id)
+	return t.GetSectionsWithContext(context.Background(), id)
+}
 
-	request, err := http.NewRequest(http.MethodGet, url, nil)
-	if err != nil {
-		return nil, err
-	}
+func (t Todoist) GetSectionsWithContext(ctx context.Context, id string) ([]Section, error) {
+	ctx = withOperation(ctx, "GetSections", RESOURCE_SECTION)
 
-	request.Header.Set("Authorization", fmt.Sprintf("Bearer %s", t.authToken))
+	path := SECTION_PATH
+	if id != "" {
+		path = fmt.Sprintf("%s?project_id=%s", SECTION_PATH, url.QueryEscape(id))
+	}
 
-	client := &http.Client{
-		Timeout: MAX_TIMEOUT,
+	request, err := t.newRequest(ctx, http.MethodGet, path, nil)
+	if err != nil {
+		return nil, err
 	}
 
-	response, err := client.Do(request)
+	response, err := t.do(request)
 	if err != nil {
 		return nil, err
 	}
 	defer response.Body.Close()
 
 	if response.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("HTTP request failed with status code: %d", response.StatusCode)
+		return nil, newAPIError(response)
 	}
 
 	data, err := io.ReadAll(response.Body)
@@ -56,31 +64,31 @@ func (t Todoist) GetSections(id string) ([]Section, error) {
 }
 
 func (t Todoist) GetSection(id string) (Section, error) {
+	return t.GetSectionWithContext(context.Background(), id)
+}
+
+func (t Todoist) GetSectionWithContext(ctx context.Context, id string) (Section, error) {
+	ctx = withOperation(ctx, "GetSection", RESOURCE_SECTION)
+
 	if id == "" {
 		return Section{}, errors.New("ID is required")
 	}
 
-	url := fmt.Sprintf("%s/%s", PROJECT_URL, id)
+	path := fmt.Sprintf("%s/%s", SECTION_PATH, id)
 
-	request, err := http.NewRequest(http.MethodGet, url, nil)
+	request, err := t.newRequest(ctx, http.MethodGet, path, nil)
 	if err != nil {
 		return Section{}, err
 	}
 
-	request.Header.Set("Authorization", fmt.Sprintf("Bearer %s", t.authToken))
-
-	client := &http.Client{
-		Timeout: MAX_TIMEOUT,
-	}
-
-	response, err := client.Do(request)
+	response, err := t.do(request)
 	if err != nil {
 		return Section{}, err
 	}
 	defer response.Body.Close()
 
 	if response.StatusCode != http.StatusOK {
-		return Section{}, fmt.Errorf("HTTP request failed with status code: %d", response.StatusCode)
+		return Section{}, newAPIError(response)
 	}
 
 	data, err := io.ReadAll(response.Body)
@@ -103,31 +111,32 @@ type AddSectionArgs struct {
 }
 
 func (t Todoist) AddSection(args AddSectionArgs) (Section, error) {
+	return t.AddSectionWithContext(context.Background(), args)
+}
+
+func (t Todoist) AddSectionWithContext(ctx context.Context, args AddSectionArgs) (Section, error) {
+	ctx = withOperation(ctx, "AddSection", RESOURCE_SECTION)
+
 	requestBody, err := json.Marshal(args)
 	if err != nil {
 		return Section{}, err
 	}
 
-	request, err := http.NewRequest(http.MethodPost, SECTION_URL, bytes.NewReader(requestBody))
+	request, err := t.newRequest(ctx, http.MethodPost, SECTION_PATH, bytes.NewReader(requestBody))
 	if err != nil {
 		return Section{}, err
 	}
 
-	request.Header.Set("Auhtorization", fmt.Sprintf("Bearer %s", t.authToken))
 	request.Header.Set("Content-Type", "application/json")
 
-	client := &http.Client{
-		Timeout: MAX_TIMEOUT,
-	}
-
-	response, err := client.Do(request)
+	response, err := t.do(request)
 	if err != nil {
 		return Section{}, err
 	}
 	defer response.Body.Close()
 
 	if response.StatusCode != http.StatusOK {
-		return Section{}, fmt.Errorf("HTTP request failed with status code: %d", response.StatusCode)
+		return Section{}, newAPIError(response)
 	}
 
 	data, err := io.ReadAll(response.Body)
@@ -148,6 +157,12 @@ type UpdateSectionArgs struct {
 }
 
 func (t Todoist) UpdateSection(args UpdateSectionArgs, id string) (Section, error) {
+	return t.UpdateSectionWithContext(context.Background(), args, id)
+}
+
+func (t Todoist) UpdateSectionWithContext(ctx context.Context, args UpdateSectionArgs, id string) (Section, error) {
+	ctx = withOperation(ctx, "UpdateSection", RESOURCE_SECTION)
+
 	if args.Name == "" {
 		return Section{}, errors.New("Name field is required")
 	}
@@ -161,28 +176,23 @@ func (t Todoist) UpdateSection(args UpdateSectionArgs, id string) (Section, erro
 		return Section{}, err
 	}
 
-	url := fmt.Sprintf("%s/%s", SECTION_URL, id)
+	path := fmt.Sprintf("%s/%s", SECTION_PATH, id)
 
-	request, err := http.NewRequest(http.MethodPost, url, bytes.NewReader(bodyRequest))
+	request, err := t.newRequest(ctx, http.MethodPost, path, bytes.NewReader(bodyRequest))
 	if err != nil {
 		return Section{}, err
 	}
 
-	request.Header.Set("Authorization", fmt.Sprintf("Bearer %s", t.authToken))
 	request.Header.Set("Content-Type", "application/json")
 
-	client := &http.Client{
-		Timeout: MAX_TIMEOUT,
-	}
-
-	response, err := client.Do(request)
+	response, err := t.do(request)
 	if err != nil {
 		return Section{}, err
 	}
 	defer response.Body.Close()
 
 	if response.StatusCode != http.StatusOK {
-		return Section{}, fmt.Errorf("HTTP request failed with status code: %d", response.StatusCode)
+		return Section{}, newAPIError(response)
 	}
 
 	data, err := io.ReadAll(response.Body)
@@ -199,32 +209,76 @@ func (t Todoist) UpdateSection(args UpdateSectionArgs, id string) (Section, erro
 }
 
 func (t Todoist) DeleteSection(id string) error {
+	return t.DeleteSectionWithContext(context.Background(), id)
+}
+
+func (t Todoist) DeleteSectionWithContext(ctx context.Context, id string) error {
+	ctx = withOperation(ctx, "DeleteSection", RESOURCE_SECTION)
+
 	if id == "" {
 		return errors.New("ID is required")
 	}
 
-	url := fmt.Sprintf("%s/%s", PROJECT_URL, id)
+	path := fmt.Sprintf("%s/%s", SECTION_PATH, id)
 
-	request, err := http.NewRequest(http.MethodDelete, url, nil)
+	request, err := t.newRequest(ctx, http.MethodDelete, path, nil)
 	if err != nil {
 		return err
 	}
 
-	request.Header.Set("Authorization", fmt.Sprintf("Bearer %s", t.authToken))
-
-	client := &http.Client{
-		Timeout: MAX_TIMEOUT,
-	}
-
-	response, err := client.Do(request)
+	response, err := t.do(request)
 	if err != nil {
 		return err
 	}
 	defer response.Body.Close()
 
 	if response.StatusCode != http.StatusNoContent {
-		return fmt.Errorf("HTTP request failed with status code: %d", response.StatusCode)
+		return newAPIError(response)
 	}
 
 	return nil
 }
+
+// MoveSection moves a section, along with its tasks, to another project.
+func (t Todoist) MoveSection(id string, projectID string) error {
+	return t.MoveSectionWithContext(context.Background(), id, projectID)
+}
+
+func (t Todoist) MoveSectionWithContext(ctx context.Context, id string, projectID string) error {
+	ctx = withOperation(ctx, "MoveSection", RESOURCE_SECTION)
+
+	if id == "" || projectID == "" {
+		return errors.New("ID and project ID are required")
+	}
+
+	return t.runCommand(ctx, newSyncCommand("section_move", map[string]any{
+		"id":         id,
+		"project_id": projectID,
+	}))
+}
+
+// ReorderSections sets the order of the given sections, all belonging to the
+// same project, to the order in which their IDs are passed.
+func (t Todoist) ReorderSections(ids []string) error {
+	return t.ReorderSectionsWithContext(context.Background(), ids)
+}
+
+func (t Todoist) ReorderSectionsWithContext(ctx context.Context, ids []string) error {
+	ctx = withOperation(ctx, "ReorderSections", RESOURCE_SECTION)
+
+	if len(ids) == 0 {
+		return errors.New("at least one section ID is required")
+	}
+
+	sections := make([]map[string]any, len(ids))
+	for i, id := range ids {
+		sections[i] = map[string]any{
+			"id":            id,
+			"section_order": i + 1,
+		}
+	}
+
+	return t.runCommand(ctx, newSyncCommand("section_reorder", map[string]any{
+		"sections": sections,
+	}))
+}
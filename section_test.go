@@ -0,0 +1,168 @@
+package todoist
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"reflect"
+	"testing"
+)
+
+func TestSectionRequestPaths(t *testing.T) {
+	server := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodDelete:
+			w.WriteHeader(http.StatusNoContent)
+		case r.URL.Path == "/sections" && r.Method == http.MethodGet:
+			fmt.Fprint(w, `[{"id":"7025","project_id":"2203306141","order":1,"name":"Groceries"}]`)
+		default:
+			fmt.Fprint(w, `{"id":"7025","project_id":"2203306141","order":1,"name":"Groceries"}`)
+		}
+	})
+	client := server.client()
+
+	tests := []struct {
+		name   string
+		call   func() error
+		method string
+		path   string
+		query  string
+	}{
+		{
+			name:   "GetSections",
+			call:   func() error { _, err := client.GetSections("2203306141"); return err },
+			method: http.MethodGet,
+			path:   "/sections",
+			query:  "project_id=2203306141",
+		},
+		{
+			name:   "GetSections without project",
+			call:   func() error { _, err := client.GetSections(""); return err },
+			method: http.MethodGet,
+			path:   "/sections",
+		},
+		{
+			name:   "GetSection",
+			call:   func() error { _, err := client.GetSection("7025"); return err },
+			method: http.MethodGet,
+			path:   "/sections/7025",
+		},
+		{
+			name: "AddSection",
+			call: func() error {
+				_, err := client.AddSection(AddSectionArgs{Name: "Groceries", ProjectID: "2203306141"})
+				return err
+			},
+			method: http.MethodPost,
+			path:   "/sections",
+		},
+		{
+			name: "UpdateSection",
+			call: func() error {
+				_, err := client.UpdateSection(UpdateSectionArgs{Name: "Supermarket"}, "7025")
+				return err
+			},
+			method: http.MethodPost,
+			path:   "/sections/7025",
+		},
+		{
+			name:   "DeleteSection",
+			call:   func() error { return client.DeleteSection("7025") },
+			method: http.MethodDelete,
+			path:   "/sections/7025",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			before := len(server.Requests())
+
+			if err := test.call(); err != nil {
+				t.Fatal(err)
+			}
+
+			requests := server.Requests()[before:]
+			if len(requests) != 1 {
+				t.Fatalf("sent %d requests, want 1", len(requests))
+			}
+
+			request := requests[0]
+			if request.Method != test.method || request.Path != test.path {
+				t.Errorf("sent %s %s, want %s %s", request.Method, request.Path, test.method, test.path)
+			}
+			if query := request.Query.Encode(); query != test.query {
+				t.Errorf("sent query %q, want %q", query, test.query)
+			}
+		})
+	}
+}
+
+func TestMoveSection(t *testing.T) {
+	server := newTestServer(t, nil)
+
+	if err := server.client().MoveSection("7025", "2203306141"); err != nil {
+		t.Fatal(err)
+	}
+
+	requests := server.Requests()
+	if len(requests) != 1 || requests[0].Method != http.MethodPost || requests[0].Path != SYNC_PATH {
+		t.Fatalf("sent %+v, want one POST %s", requests, SYNC_PATH)
+	}
+
+	commands := requests[0].commands(t)
+	if len(commands) != 1 || commands[0].Type != "section_move" || commands[0].UUID == "" {
+		t.Fatalf("sent commands %+v", commands)
+	}
+
+	want := map[string]any{"id": "7025", "project_id": "2203306141"}
+	if !reflect.DeepEqual(commands[0].Args, want) {
+		t.Errorf("sent args %v, want %v", commands[0].Args, want)
+	}
+}
+
+func TestReorderSections(t *testing.T) {
+	server := newTestServer(t, nil)
+
+	if err := server.client().ReorderSections([]string{"3", "1", "2"}); err != nil {
+		t.Fatal(err)
+	}
+
+	commands := server.Requests()[0].commands(t)
+	if len(commands) != 1 || commands[0].Type != "section_reorder" {
+		t.Fatalf("sent commands %+v", commands)
+	}
+
+	var want map[string]any
+	json.Unmarshal([]byte(`{"sections":[
+		{"id":"3","section_order":1},
+		{"id":"1","section_order":2},
+		{"id":"2","section_order":3}
+	]}`), &want)
+
+	if !reflect.DeepEqual(commands[0].Args, want) {
+		t.Errorf("sent args %v, want %v", commands[0].Args, want)
+	}
+}
+
+func TestSectionCommandError(t *testing.T) {
+	server := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		r.ParseForm()
+
+		var commands []syncCommand
+		json.Unmarshal([]byte(r.PostForm.Get("commands")), &commands)
+
+		json.NewEncoder(w).Encode(map[string]any{
+			"sync_status": map[string]any{
+				commands[0].UUID: map[string]any{"error_code": 20, "error": "Section not found"},
+			},
+		})
+	})
+
+	err := server.client().MoveSection("404", "2203306141")
+
+	var commandErr *CommandError
+	if !errors.As(err, &commandErr) || commandErr.Code != 20 || commandErr.CommandType != "section_move" {
+		t.Fatalf("got %v, want a section_move *CommandError", err)
+	}
+}
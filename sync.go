@@ -0,0 +1,323 @@
+package todoist
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+
+	"github.com/felipeornelis/todoist-go-client/pkg"
+)
+
+const (
+	SYNC_BASE_URL = "https://api.todoist.com/sync/v9"
+	SYNC_PATH     = "/sync"
+)
+
+// WithSyncURL points the Sync API calls at another endpoint, the same way
+// WithBaseURL does for the REST API.
+func WithSyncURL(syncURL string) Option {
+	return func(t *Todoist) {
+		t.syncURL = strings.TrimSuffix(syncURL, "/")
+	}
+}
+
+type syncCommand struct {
+	Type   string         `json:"type"`
+	UUID   string         `json:"uuid"`
+	TempID string         `json:"temp_id,omitempty"`
+	Args   map[string]any `json:"args"`
+}
+
+func newSyncCommand(commandType string, args map[string]any) syncCommand {
+	return syncCommand{
+		Type: commandType,
+		UUID: pkg.NewUUID(),
+		Args: args,
+	}
+}
+
+type syncCommandsResponse struct {
+	SyncToken     string                     `json:"sync_token"`
+	SyncStatus    map[string]json.RawMessage `json:"sync_status"`
+	TempIDMapping map[string]string          `json:"temp_id_mapping"`
+}
+
+// CommandError is the error Todoist reports for a single Sync API command.
+type CommandError struct {
+	CommandType string
+	UUID        string
+	TempID      string
+	Code        int    `json:"error_code"`
+	Message     string `json:"error"`
+}
+
+func (e *CommandError) Error() string {
+	return fmt.Sprintf("%s command %s failed with error code %d: %s", e.CommandType, e.UUID, e.Code, e.Message)
+}
+
+// commandError returns the error reported for command, or nil if it
+// succeeded.
+func (r syncCommandsResponse) commandError(command syncCommand) error {
+	status, ok := r.SyncStatus[command.UUID]
+	if !ok {
+		return &CommandError{CommandType: command.Type, UUID: command.UUID, TempID: command.TempID, Message: "missing sync status"}
+	}
+
+	var value string
+	if err := json.Unmarshal(status, &value); err == nil && value == "ok" {
+		return nil
+	}
+
+	commandErr := &CommandError{CommandType: command.Type, UUID: command.UUID, TempID: command.TempID}
+	if err := json.Unmarshal(status, commandErr); err != nil {
+		commandErr.Message = string(status)
+	}
+
+	return commandErr
+}
+
+func (t Todoist) postSync(ctx context.Context, path string, form url.Values, out any) error {
+	request, err := t.newSyncRequest(ctx, http.MethodPost, path, strings.NewReader(form.Encode()))
+	if err != nil {
+		return err
+	}
+
+	request.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	response, err := t.do(request)
+	if err != nil {
+		return err
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode != http.StatusOK {
+		return newAPIError(response)
+	}
+
+	data, err := io.ReadAll(response.Body)
+	if err != nil {
+		return err
+	}
+
+	return json.Unmarshal(data, out)
+}
+
+func (t Todoist) runCommands(ctx context.Context, commands ...syncCommand) (syncCommandsResponse, error) {
+	encoded, err := json.Marshal(commands)
+	if err != nil {
+		return syncCommandsResponse{}, err
+	}
+
+	var result syncCommandsResponse
+	if err := t.postSync(ctx, SYNC_PATH, url.Values{"commands": {string(encoded)}}, &result); err != nil {
+		return syncCommandsResponse{}, err
+	}
+
+	return result, nil
+}
+
+// runCommand sends a single command and returns its error, if any.
+func (t Todoist) runCommand(ctx context.Context, command syncCommand) error {
+	result, err := t.runCommands(ctx, command)
+	if err != nil {
+		return err
+	}
+
+	return result.commandError(command)
+}
+
+const FULL_SYNC_TOKEN = "*"
+
+// SyncArgs selects what a Sync call fetches. An empty SyncToken requests a
+// full sync, and empty ResourceTypes requests every resource type.
+type SyncArgs struct {
+	SyncToken     string
+	ResourceTypes []string
+}
+
+var defaultResourceTypes = []string{"items", "projects", "sections", "labels", "notes", "project_notes", "collaborators"}
+
+// SyncDeleted holds the IDs of the resources deleted since the sync token.
+type SyncDeleted struct {
+	Tasks    []string
+	Projects []string
+	Sections []string
+	Labels   []string
+	Comments []string
+}
+
+// SyncResult is an account snapshot for a full sync, or the resources
+// created or updated since the given token for an incremental one.
+type SyncResult struct {
+	SyncToken     string
+	FullSync      bool
+	Tasks         []Task
+	Projects      []Project
+	Sections      []Section
+	Labels        []Label
+	Comments      []Comment
+	Collaborators []GetAllCollaboratorsOutput
+	Deleted       SyncDeleted
+}
+
+// HasChanges reports whether anything was created, updated or deleted.
+func (r SyncResult) HasChanges() bool {
+	return len(r.Tasks)+len(r.Projects)+len(r.Sections)+len(r.Labels)+len(r.Comments)+len(r.Collaborators) > 0 ||
+		len(r.Deleted.Tasks)+len(r.Deleted.Projects)+len(r.Deleted.Sections)+len(r.Deleted.Labels)+len(r.Deleted.Comments) > 0
+}
+
+type syncResponse struct {
+	SyncToken     string             `json:"sync_token"`
+	FullSync      bool               `json:"full_sync"`
+	Items         []syncItem         `json:"items"`
+	Projects      []syncProject      `json:"projects"`
+	Sections      []syncSection      `json:"sections"`
+	Labels        []syncLabel        `json:"labels"`
+	Notes         []syncNote         `json:"notes"`
+	ProjectNotes  []syncNote         `json:"project_notes"`
+	Collaborators []syncCollaborator `json:"collaborators"`
+}
+
+func (r syncResponse) toResult() (SyncResult, error) {
+	result := SyncResult{
+		SyncToken: r.SyncToken,
+		FullSync:  r.FullSync,
+	}
+
+	for _, item := range r.Items {
+		if item.IsDeleted {
+			result.Deleted.Tasks = append(result.Deleted.Tasks, item.ID)
+			continue
+		}
+
+		task, err := item.toTask()
+		if err != nil {
+			return SyncResult{}, err
+		}
+		result.Tasks = append(result.Tasks, task)
+	}
+
+	for _, project := range r.Projects {
+		if project.IsDeleted {
+			result.Deleted.Projects = append(result.Deleted.Projects, project.ID)
+			continue
+		}
+		result.Projects = append(result.Projects, project.toProject())
+	}
+
+	for _, section := range r.Sections {
+		if section.IsDeleted {
+			result.Deleted.Sections = append(result.Deleted.Sections, section.ID)
+			continue
+		}
+		result.Sections = append(result.Sections, section.toSection())
+	}
+
+	for _, label := range r.Labels {
+		if label.IsDeleted {
+			result.Deleted.Labels = append(result.Deleted.Labels, label.ID)
+			continue
+		}
+		result.Labels = append(result.Labels, label.toLabel())
+	}
+
+	for _, note := range append(r.Notes, r.ProjectNotes...) {
+		if note.IsDeleted {
+			result.Deleted.Comments = append(result.Deleted.Comments, note.ID)
+			continue
+		}
+		result.Comments = append(result.Comments, note.toComment())
+	}
+
+	for _, collaborator := range r.Collaborators {
+		result.Collaborators = append(result.Collaborators, collaborator.toCollaborator())
+	}
+
+	return result, nil
+}
+
+func (t Todoist) Sync(args SyncArgs) (SyncResult, error) {
+	return t.SyncWithContext(context.Background(), args)
+}
+
+func (t Todoist) SyncWithContext(ctx context.Context, args SyncArgs) (SyncResult, error) {
+	ctx = withOperation(ctx, "Sync", RESOURCE_SYNC)
+
+	syncToken := args.SyncToken
+	if syncToken == "" {
+		syncToken = FULL_SYNC_TOKEN
+	}
+
+	resourceTypes := args.ResourceTypes
+	if len(resourceTypes) == 0 {
+		resourceTypes = defaultResourceTypes
+	}
+
+	encoded, err := json.Marshal(resourceTypes)
+	if err != nil {
+		return SyncResult{}, err
+	}
+
+	form := url.Values{
+		"sync_token":     {syncToken},
+		"resource_types": {string(encoded)},
+	}
+
+	var response syncResponse
+	if err := t.postSync(ctx, SYNC_PATH, form, &response); err != nil {
+		return SyncResult{}, err
+	}
+
+	return response.toResult()
+}
+
+// Syncer keeps the sync token between calls, so each Sync only returns what
+// changed since the previous one. It is safe for concurrent use.
+type Syncer struct {
+	client        Todoist
+	resourceTypes []string
+
+	mu        sync.Mutex
+	syncToken string
+}
+
+// NewSyncer returns a Syncer starting from syncToken, or with a full sync
+// when it is empty.
+func (t Todoist) NewSyncer(syncToken string, resourceTypes ...string) *Syncer {
+	return &Syncer{
+		client:        t,
+		resourceTypes: resourceTypes,
+		syncToken:     syncToken,
+	}
+}
+
+// SyncToken returns the token of the last successful sync, which may be
+// persisted to resume incremental syncs later.
+func (s *Syncer) SyncToken() string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.syncToken
+}
+
+func (s *Syncer) Sync(ctx context.Context) (SyncResult, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	result, err := s.client.SyncWithContext(ctx, SyncArgs{
+		SyncToken:     s.syncToken,
+		ResourceTypes: s.resourceTypes,
+	})
+	if err != nil {
+		return SyncResult{}, err
+	}
+
+	s.syncToken = result.SyncToken
+
+	return result, nil
+}
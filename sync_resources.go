@@ -0,0 +1,218 @@
+package todoist
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// The Sync API describes resources with other field names than the REST API.
+// The types below decode them and convert them into the REST types.
+
+type syncDue struct {
+	Date        string `json:"date"`
+	Timezone    string `json:"timezone"`
+	String      string `json:"string"`
+	Lang        string `json:"lang"`
+	IsRecurring bool   `json:"is_recurring"`
+}
+
+// toTaskDue splits the Sync API date, which holds either a day or a
+// datetime, into the REST representation.
+func (d *syncDue) toTaskDue() (TaskDue, error) {
+	if d == nil || d.Date == "" {
+		return TaskDue{}, nil
+	}
+
+	due := TaskDue{
+		String:      d.String,
+		IsRecurring: d.IsRecurring,
+		Timezone:    d.Timezone,
+	}
+
+	if !strings.Contains(d.Date, "T") {
+		if err := due.Date.UnmarshalJSON([]byte(fmt.Sprintf("%q", d.Date))); err != nil {
+			return TaskDue{}, err
+		}
+		return due, nil
+	}
+
+	if err := due.Datetime.UnmarshalJSON([]byte(fmt.Sprintf("%q", d.Date))); err != nil {
+		return TaskDue{}, err
+	}
+
+	day := due.Datetime.Time
+	if !due.Datetime.Floating && due.Timezone != "" {
+		if loc, err := time.LoadLocation(due.Timezone); err == nil {
+			day = day.In(loc)
+		}
+	}
+	due.Date = NewDate(day.Year(), day.Month(), day.Day())
+
+	return due, nil
+}
+
+type syncItem struct {
+	ID             string        `json:"id"`
+	ProjectID      string        `json:"project_id"`
+	SectionID      string        `json:"section_id"`
+	ParentID       string        `json:"parent_id"`
+	Content        string        `json:"content"`
+	Description    string        `json:"description"`
+	Priority       uint8         `json:"priority"`
+	Due            *syncDue      `json:"due"`
+	Duration       *TaskDuration `json:"duration"`
+	ChildOrder     int           `json:"child_order"`
+	Labels         []string      `json:"labels"`
+	AddedByUID     string        `json:"added_by_uid"`
+	AssignedByUID  string        `json:"assigned_by_uid"`
+	ResponsibleUID string        `json:"responsible_uid"`
+	Checked        bool          `json:"checked"`
+	IsDeleted      bool          `json:"is_deleted"`
+	AddedAt        DateTime      `json:"added_at"`
+	CompletedAt    DateTime      `json:"completed_at"`
+	NoteCount      int           `json:"note_count"`
+}
+
+func (i syncItem) toTask() (Task, error) {
+	due, err := i.Due.toTaskDue()
+	if err != nil {
+		return Task{}, fmt.Errorf("item %s: %w", i.ID, err)
+	}
+
+	task := Task{
+		ID:           i.ID,
+		ProjectID:    i.ProjectID,
+		SectionID:    i.SectionID,
+		Content:      i.Content,
+		Description:  i.Description,
+		IsCompleted:  i.Checked,
+		Labels:       i.Labels,
+		ParentID:     i.ParentID,
+		Order:        i.ChildOrder,
+		Priority:     i.Priority,
+		Due:          due,
+		URL:          fmt.Sprintf("https://todoist.com/showTask?id=%s", i.ID),
+		CommentCount: i.NoteCount,
+		CreatedAt:    i.AddedAt,
+		AssigneeID:   i.ResponsibleUID,
+		AssignerID:   i.AssignedByUID,
+	}
+
+	if i.Duration != nil {
+		task.Duration = *i.Duration
+	}
+
+	return task, nil
+}
+
+type syncProject struct {
+	ID           string `json:"id"`
+	Name         string `json:"name"`
+	Color        string `json:"color"`
+	ParentID     string `json:"parent_id"`
+	ChildOrder   int    `json:"child_order"`
+	Shared       bool   `json:"shared"`
+	IsFavorite   bool   `json:"is_favorite"`
+	InboxProject bool   `json:"inbox_project"`
+	TeamInbox    bool   `json:"team_inbox"`
+	ViewStyle    string `json:"view_style"`
+	IsArchived   bool   `json:"is_archived"`
+	IsDeleted    bool   `json:"is_deleted"`
+}
+
+func (p syncProject) toProject() Project {
+	return Project{
+		ID:             p.ID,
+		Name:           p.Name,
+		Color:          p.Color,
+		ParentID:       p.ParentID,
+		Order:          p.ChildOrder,
+		IsShared:       p.Shared,
+		IsFavorite:     p.IsFavorite,
+		IsInboxProject: p.InboxProject,
+		IsTeamInbox:    p.TeamInbox,
+		ViewStyle:      p.ViewStyle,
+		IsArchived:     p.IsArchived,
+		URL:            fmt.Sprintf("https://todoist.com/showProject?id=%s", p.ID),
+	}
+}
+
+type syncSection struct {
+	ID           string `json:"id"`
+	ProjectID    string `json:"project_id"`
+	Name         string `json:"name"`
+	SectionOrder int    `json:"section_order"`
+	IsDeleted    bool   `json:"is_deleted"`
+}
+
+func (s syncSection) toSection() Section {
+	return Section{
+		ID:        s.ID,
+		ProjectID: s.ProjectID,
+		Order:     s.SectionOrder,
+		Name:      s.Name,
+	}
+}
+
+type syncLabel struct {
+	ID         string `json:"id"`
+	Name       string `json:"name"`
+	Color      string `json:"color"`
+	ItemOrder  int    `json:"item_order"`
+	IsFavorite bool   `json:"is_favorite"`
+	IsDeleted  bool   `json:"is_deleted"`
+}
+
+func (l syncLabel) toLabel() Label {
+	return Label{
+		ID:         l.ID,
+		Name:       l.Name,
+		Color:      l.Color,
+		Order:      l.ItemOrder,
+		IsFavorite: l.IsFavorite,
+	}
+}
+
+type syncNote struct {
+	ID             string            `json:"id"`
+	ItemID         string            `json:"item_id"`
+	ProjectID      string            `json:"project_id"`
+	Content        string            `json:"content"`
+	PostedAt       DateTime          `json:"posted_at"`
+	FileAttachment CommentAttachment `json:"file_attachment"`
+	IsDeleted      bool              `json:"is_deleted"`
+}
+
+// toComment converts both task notes and project notes. Task notes also
+// carry their project ID in the Sync API, which the REST API leaves empty.
+func (n syncNote) toComment() Comment {
+	comment := Comment{
+		ID:         n.ID,
+		TaskID:     n.ItemID,
+		ProjectID:  n.ProjectID,
+		PostedAt:   n.PostedAt,
+		Content:    n.Content,
+		Attachment: n.FileAttachment,
+	}
+
+	if comment.TaskID != "" {
+		comment.ProjectID = ""
+	}
+
+	return comment
+}
+
+type syncCollaborator struct {
+	ID       string `json:"id"`
+	Email    string `json:"email"`
+	FullName string `json:"full_name"`
+}
+
+func (c syncCollaborator) toCollaborator() GetAllCollaboratorsOutput {
+	return GetAllCollaboratorsOutput{
+		ID:    c.ID,
+		Name:  c.FullName,
+		Email: c.Email,
+	}
+}
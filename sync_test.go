@@ -0,0 +1,260 @@
+package todoist
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"os"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestSyncDueToTaskDue(t *testing.T) {
+	if _, err := time.LoadLocation("America/Los_Angeles"); err != nil {
+		t.Skip(err)
+	}
+
+	tests := []struct {
+		name         string
+		due          *syncDue
+		wantDate     Date
+		wantDatetime string
+		wantFloating bool
+		wantErr      bool
+	}{
+		{"no due", nil, Date{}, "", false, false},
+		{"empty date", &syncDue{}, Date{}, "", false, false},
+		{"date only", &syncDue{Date: "2026-10-20"}, NewDate(2026, time.October, 20), "", false, false},
+		{"utc datetime", &syncDue{Date: "2026-10-18T03:30:00Z"}, NewDate(2026, time.October, 18), "2026-10-18T03:30:00Z", false, false},
+		{"timezone shifts the day", &syncDue{Date: "2026-10-18T03:30:00Z", Timezone: "America/Los_Angeles"}, NewDate(2026, time.October, 17), "2026-10-18T03:30:00Z", false, false},
+		{"unknown timezone", &syncDue{Date: "2026-10-18T03:30:00Z", Timezone: "Mars/Olympus"}, NewDate(2026, time.October, 18), "2026-10-18T03:30:00Z", false, false},
+		{"floating datetime", &syncDue{Date: "2026-10-18T23:00:00"}, NewDate(2026, time.October, 18), "2026-10-18T23:00:00", true, false},
+		{"floating ignores timezone", &syncDue{Date: "2026-10-18T23:00:00", Timezone: "America/Los_Angeles"}, NewDate(2026, time.October, 18), "2026-10-18T23:00:00", true, false},
+		{"invalid date", &syncDue{Date: "20 Oct"}, Date{}, "", false, true},
+		{"invalid datetime", &syncDue{Date: "2026-10-18T25:00"}, Date{}, "", false, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			due, err := tt.due.toTaskDue()
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("err = %v, want error %t", err, tt.wantErr)
+			}
+			if due.Date != tt.wantDate {
+				t.Errorf("date = %s, want %s", due.Date, tt.wantDate)
+			}
+			if got := due.Datetime.String(); got != tt.wantDatetime {
+				t.Errorf("datetime = %q, want %q", got, tt.wantDatetime)
+			}
+			if due.Datetime.Floating != tt.wantFloating {
+				t.Errorf("floating = %t, want %t", due.Datetime.Floating, tt.wantFloating)
+			}
+		})
+	}
+}
+
+// syncFixture serves testdata/<name>.json to every request.
+func syncFixture(t *testing.T, name string) *testServer {
+	t.Helper()
+
+	fixture, err := os.ReadFile("testdata/" + name + ".json")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Write(fixture)
+	})
+}
+
+func TestSyncFull(t *testing.T) {
+	if _, err := time.LoadLocation("America/Los_Angeles"); err != nil {
+		t.Skip(err)
+	}
+
+	server := syncFixture(t, "sync_full")
+
+	result, err := server.client().Sync(SyncArgs{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	request := server.Requests()[0]
+	form, _ := url.ParseQuery(request.Body)
+	if request.Method != http.MethodPost || request.Path != SYNC_PATH || form.Get("sync_token") != FULL_SYNC_TOKEN {
+		t.Errorf("request = %s %s %v", request.Method, request.Path, form)
+	}
+	if got := form.Get("resource_types"); got != `["items","projects","sections","labels","notes","project_notes","collaborators"]` {
+		t.Errorf("resource_types = %s", got)
+	}
+
+	if !result.FullSync || result.SyncToken != "VRyFHr0Qo3Hr--pzINyT6nax4vW7X2YG5RQlw3lB-6eYOPbSZVJepa62EVhO" {
+		t.Errorf("full sync = %t, token = %q", result.FullSync, result.SyncToken)
+	}
+	if !reflect.DeepEqual(result.Deleted, SyncDeleted{}) {
+		t.Errorf("deleted = %+v, want none", result.Deleted)
+	}
+	if len(result.Tasks) != 3 || len(result.Projects) != 2 || len(result.Sections) != 1 || len(result.Labels) != 1 || len(result.Collaborators) != 1 {
+		t.Fatalf("got %d tasks, %d projects, %d sections, %d labels, %d collaborators",
+			len(result.Tasks), len(result.Projects), len(result.Sections), len(result.Labels), len(result.Collaborators))
+	}
+
+	taxes, call, stretch := result.Tasks[0], result.Tasks[1], result.Tasks[2]
+	if taxes.Due.Date != NewDate(2026, time.October, 20) || !taxes.Due.Datetime.IsZero() || taxes.Due.String != "Oct 20" {
+		t.Errorf("date only due = %+v", taxes.Due)
+	}
+	if taxes.SectionID != "7025" || taxes.Priority != 4 || taxes.CommentCount != 1 || !reflect.DeepEqual(taxes.Labels, []string{"finance"}) {
+		t.Errorf("task = %+v", taxes)
+	}
+
+	// 03:30 UTC on the 18th is still the evening of the 17th in Los Angeles.
+	if call.Due.Date != NewDate(2026, time.October, 17) || call.Due.Timezone != "America/Los_Angeles" ||
+		!call.Due.Datetime.Equal(time.Date(2026, time.October, 18, 3, 30, 0, 0, time.UTC)) {
+		t.Errorf("timezone due = %+v", call.Due)
+	}
+	if call.Duration != (TaskDuration{Amount: 45, Unit: "minute"}) || call.AssigneeID != "2671362" || call.AssignerID != "2671355" {
+		t.Errorf("task = %+v", call)
+	}
+
+	if stretch.Due.Date != NewDate(2026, time.October, 18) || !stretch.Due.Datetime.Floating || !stretch.Due.IsRecurring {
+		t.Errorf("floating due = %+v", stretch.Due)
+	}
+	if stretch.ParentID != "2995104339" || stretch.URL != "https://todoist.com/showTask?id=2995104341" {
+		t.Errorf("task = %+v", stretch)
+	}
+
+	if inbox := result.Projects[0]; !inbox.IsInboxProject || inbox.Name != "Inbox" {
+		t.Errorf("inbox = %+v", inbox)
+	}
+	if admin := result.Projects[1]; !admin.IsShared || !admin.IsFavorite || admin.ViewStyle != "board" || admin.Order != 1 {
+		t.Errorf("project = %+v", admin)
+	}
+	if label := result.Labels[0]; label != (Label{ID: "2156154810", Name: "finance", Color: "berry_red", Order: 1, IsFavorite: true}) {
+		t.Errorf("label = %+v", label)
+	}
+	if collaborator := result.Collaborators[0]; collaborator.Name != "Ana Lima" || collaborator.Email != "ana@example.com" {
+		t.Errorf("collaborator = %+v", collaborator)
+	}
+
+	// Task notes carry their project in the Sync API; project notes only
+	// have the project.
+	wantComments := []struct{ id, taskID, projectID string }{
+		{"2992679862", "2995104339", ""},
+		{"2992679863", "", "2203306141"},
+	}
+	if len(result.Comments) != len(wantComments) {
+		t.Fatalf("got %d comments, want %d", len(result.Comments), len(wantComments))
+	}
+	for i, want := range wantComments {
+		comment := result.Comments[i]
+		if comment.ID != want.id || comment.TaskID != want.taskID || comment.ProjectID != want.projectID {
+			t.Errorf("comment %d = %+v, want task %q, project %q", i, comment, want.taskID, want.projectID)
+		}
+	}
+	if attachment := result.Comments[1].Attachment; attachment.FileName != "budget.pdf" || attachment.ResourceType != "file" {
+		t.Errorf("attachment = %+v", attachment)
+	}
+}
+
+func TestSyncIncremental(t *testing.T) {
+	server := syncFixture(t, "sync_incremental")
+
+	result, err := server.client().Sync(SyncArgs{
+		SyncToken:     "VRyFHr0Qo3Hr--pzINyT6nax4vW7X2YG5RQlw3lB-6eYOPbSZVJepa62EVhO",
+		ResourceTypes: []string{"items", "labels", "notes", "project_notes", "sections"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	form, _ := url.ParseQuery(server.Requests()[0].Body)
+	if form.Get("sync_token") != "VRyFHr0Qo3Hr--pzINyT6nax4vW7X2YG5RQlw3lB-6eYOPbSZVJepa62EVhO" ||
+		form.Get("resource_types") != `["items","labels","notes","project_notes","sections"]` {
+		t.Errorf("form = %v", form)
+	}
+
+	if result.FullSync || !result.HasChanges() {
+		t.Errorf("full sync = %t, has changes = %t", result.FullSync, result.HasChanges())
+	}
+
+	if len(result.Tasks) != 1 || result.Tasks[0].Content != "File taxes before the deadline" || result.Tasks[0].Due.Date != NewDate(2026, time.October, 21) {
+		t.Errorf("tasks = %+v", result.Tasks)
+	}
+	if len(result.Labels)+len(result.Sections)+len(result.Comments) != 0 {
+		t.Errorf("deleted entries returned as updates: %+v", result)
+	}
+
+	want := SyncDeleted{
+		Tasks:    []string{"2995104341"},
+		Sections: []string{"7025"},
+		Labels:   []string{"2156154810"},
+		Comments: []string{"2992679862", "2992679863"},
+	}
+	if !reflect.DeepEqual(result.Deleted, want) {
+		t.Errorf("deleted = %+v, want %+v", result.Deleted, want)
+	}
+}
+
+func TestSyncNoChanges(t *testing.T) {
+	server := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"full_sync": false, "items": [], "projects": [], "sync_token": "next"}`))
+	})
+
+	result, err := server.client().Sync(SyncArgs{SyncToken: "previous"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.HasChanges() || result.SyncToken != "next" {
+		t.Errorf("result = %+v", result)
+	}
+}
+
+func TestSyncInvalidDue(t *testing.T) {
+	server := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"full_sync": true, "items": [{"id": "1", "due": {"date": "someday"}}], "sync_token": "next"}`))
+	})
+
+	if _, err := server.client().Sync(SyncArgs{}); err == nil {
+		t.Fatal("sync with an invalid due date succeeded")
+	}
+}
+
+func TestSyncerToken(t *testing.T) {
+	full, err := os.ReadFile("testdata/sync_full.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	incremental, err := os.ReadFile("testdata/sync_incremental.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	server := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.FormValue("sync_token") == FULL_SYNC_TOKEN {
+			w.Write(full)
+			return
+		}
+		w.Write(incremental)
+	})
+
+	syncer := server.client().NewSyncer("", "items")
+	for _, wantFull := range []bool{true, false} {
+		result, err := syncer.Sync(context.Background())
+		if err != nil {
+			t.Fatal(err)
+		}
+		if result.FullSync != wantFull || syncer.SyncToken() != result.SyncToken {
+			t.Errorf("full sync = %t, syncer token = %q, result token = %q", result.FullSync, syncer.SyncToken(), result.SyncToken)
+		}
+	}
+
+	var tokens []string
+	for _, request := range server.Requests() {
+		form, _ := url.ParseQuery(request.Body)
+		tokens = append(tokens, form.Get("sync_token"))
+	}
+	if want := []string{FULL_SYNC_TOKEN, "VRyFHr0Qo3Hr--pzINyT6nax4vW7X2YG5RQlw3lB-6eYOPbSZVJepa62EVhO"}; !reflect.DeepEqual(tokens, want) {
+		t.Errorf("sync tokens sent = %v, want %v", tokens, want)
+	}
+}
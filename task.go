@@ -2,67 +2,116 @@ package todoist
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
 	"io"
 	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+const (
+	TASK_PATH = "/tasks"
+	TASK_URL  = BASE_URL + TASK_PATH
 
-	"github.com/felipeornelis/todoist-go-client/pkg"
+	QUICK_ADD_PATH = "/quick/add"
+	TASK_INFO_PATH = "/items/get"
 )
 
 type Task struct {
-	ID           string
-	ProjectID    string
-	SectionID    string
-	Content      string
-	Description  string
-	IsCompleted  bool
-	Labels       []string
-	ParentID     string
-	Order        uint8
-	Priority     uint8
-	Due          taskDue
-	URL          string
-	CommentCount int
-	CreatedAt    string
-	AssigneeID   string
-	AssignerID   string
-	Duration     taskDuration
-}
-
-type taskDue struct {
-	String      string
-	Date        string
-	IsRecurring bool
-	Datetime    string
-	Timezone    string
-}
-
-type taskDuration struct {
-	Amount uint
-	Unit   string
+	ID           string       `json:"id"`
+	ProjectID    string       `json:"project_id"`
+	SectionID    string       `json:"section_id"`
+	Content      string       `json:"content"`
+	Description  string       `json:"description"`
+	IsCompleted  bool         `json:"is_completed"`
+	Labels       []string     `json:"labels"`
+	ParentID     string       `json:"parent_id"`
+	Order        int          `json:"order"`
+	Priority     uint8        `json:"priority"`
+	Due          TaskDue      `json:"due"`
+	URL          string       `json:"url"`
+	CommentCount int          `json:"comment_count"`
+	CreatedAt    DateTime     `json:"created_at"`
+	AssigneeID   string       `json:"assignee_id"`
+	AssignerID   string       `json:"assigner_id"`
+	Duration     TaskDuration `json:"duration"`
+}
+
+type TaskDue struct {
+	String      string   `json:"string"`
+	Date        Date     `json:"date"`
+	IsRecurring bool     `json:"is_recurring"`
+	Datetime    DateTime `json:"datetime"`
+	Timezone    string   `json:"timezone"`
+}
+
+// IsAllDay reports whether the task is due on a day rather than at a
+// specific time.
+func (d TaskDue) IsAllDay() bool {
+	return d.Datetime.IsZero()
+}
+
+// Time returns when the task is due, in the due's timezone when it has one.
+// All-day dates resolve to midnight, and floating datetimes and dates without
+// a timezone are kept in time.Local. A task without due date yields the zero
+// time.
+func (d TaskDue) Time() (time.Time, error) {
+	loc := time.Local
+	if d.Timezone != "" {
+		var err error
+		if loc, err = time.LoadLocation(d.Timezone); err != nil {
+			return time.Time{}, err
+		}
+	}
+
+	if !d.Datetime.IsZero() {
+		if d.Datetime.Floating {
+			return d.Datetime.Time, nil
+		}
+		return d.Datetime.In(loc), nil
+	}
+
+	if d.Date.IsZero() {
+		return time.Time{}, nil
+	}
+
+	return d.Date.Midnight(loc), nil
+}
+
+type TaskDuration struct {
+	Amount uint   `json:"amount"`
+	Unit   string `json:"unit"`
 }
 
 type AddTaskArgs struct {
-	Content      string   `json:"content"`
-	Description  string   `json:"description,omitempty"`
-	ProjectID    string   `json:"project_id,omitempty"`
-	SectionID    string   `json:"section_id,omitempty"`
-	ParentID     string   `json:"parent_id,omitempty"`
-	Order        uint8    `json:"order,omitempty"`
-	Labels       []string `json:"labels,omitempty"`
-	Priority     uint8    `json:"priority,omitempty"`
-	DueString    string   `json:"due_string,omitempty"`
-	DueDate      string   `json:"due_date,omitempty"`
-	DueDatetime  string   `json:"due_datetime,omitempty"`
-	DueLang      string   `json:"due_lang,omitempty"`
-	AssigneeID   string   `json:"assignee_id,omitempty"`
-	Duration     uint     `json:"duration,omitempty"`
-	DurationUnit string   `json:"duration_unit,omitempty"`
+	Content      string    `json:"content"`
+	Description  string    `json:"description,omitempty"`
+	ProjectID    string    `json:"project_id,omitempty"`
+	SectionID    string    `json:"section_id,omitempty"`
+	ParentID     string    `json:"parent_id,omitempty"`
+	Order        int       `json:"order,omitempty"`
+	Labels       []string  `json:"labels,omitempty"`
+	Priority     uint8     `json:"priority,omitempty"`
+	DueString    string    `json:"due_string,omitempty"`
+	DueDate      *Date     `json:"due_date,omitempty"`
+	DueDatetime  *DateTime `json:"due_datetime,omitempty"`
+	DueLang      string    `json:"due_lang,omitempty"`
+	AssigneeID   string    `json:"assignee_id,omitempty"`
+	Duration     uint      `json:"duration,omitempty"`
+	DurationUnit string    `json:"duration_unit,omitempty"`
 }
 
 func (t Todoist) AddTask(args AddTaskArgs) (Task, error) {
+	return t.AddTaskWithContext(context.Background(), args)
+}
+
+func (t Todoist) AddTaskWithContext(ctx context.Context, args AddTaskArgs) (Task, error) {
+	ctx = withOperation(ctx, "AddTask", RESOURCE_TASK)
+
 	if args.Content == "" {
 		return Task{}, errors.New("`Content` field is required")
 	}
@@ -72,34 +121,26 @@ func (t Todoist) AddTask(args AddTaskArgs) (Task, error) {
 		return Task{}, err
 	}
 
-	url := "https://api.todoist.com/rest/v2/tasks"
-
-	client := &http.Client{
-		Timeout: MAX_TIMEOUT,
-	}
-
-	request, err := http.NewRequest(http.MethodPost, url, bytes.NewReader(bodyRequest))
+	request, err := t.newRequest(ctx, http.MethodPost, TASK_PATH, bytes.NewReader(bodyRequest))
 	if err != nil {
 		return Task{}, err
 	}
 
-	request.Header.Set("Authorization", fmt.Sprintf("Bearer %s", t.authToken))
-	request.Header.Set("X-Request-Id", pkg.NewUUID())
 	request.Header.Set("Content-Type", "application/json")
 
-	response, err := client.Do(request)
+	response, err := t.do(request)
 	if err != nil {
 		return Task{}, err
 	}
 	defer response.Body.Close()
 
 	if response.StatusCode != http.StatusOK {
-		return Task{}, fmt.Errorf("HTTP request failed with status code: %d", response.StatusCode)
+		return Task{}, newAPIError(response)
 	}
 
 	data, err := io.ReadAll(response.Body)
 	if err != nil {
-		return Task{}, nil
+		return Task{}, err
 	}
 
 	var task Task
@@ -129,28 +170,166 @@ func (t Todoist) AddTask(args AddTaskArgs) (Task, error) {
 }
 
 func (t Todoist) GetTask(id string) (Task, error) {
-	return Task{}, nil
+	return t.GetTaskWithContext(context.Background(), id)
+}
+
+func (t Todoist) GetTaskWithContext(ctx context.Context, id string) (Task, error) {
+	ctx = withOperation(ctx, "GetTask", RESOURCE_TASK)
+
+	if id == "" {
+		return Task{}, errors.New("ID is required")
+	}
+
+	path := fmt.Sprintf("%s/%s", TASK_PATH, id)
+
+	request, err := t.newRequest(ctx, http.MethodGet, path, nil)
+	if err != nil {
+		return Task{}, err
+	}
+
+	response, err := t.do(request)
+	if err != nil {
+		return Task{}, err
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode != http.StatusOK {
+		return Task{}, newAPIError(response)
+	}
+
+	data, err := io.ReadAll(response.Body)
+	if err != nil {
+		return Task{}, err
+	}
+
+	var task Task
+	if err := json.Unmarshal(data, &task); err != nil {
+		return Task{}, err
+	}
+
+	return task, nil
+}
+
+// GetTaskInfo returns a task whether it is active or completed, using the
+// Sync API. GetTask only finds active tasks.
+func (t Todoist) GetTaskInfo(id string) (Task, error) {
+	return t.GetTaskInfoWithContext(context.Background(), id)
+}
+
+func (t Todoist) GetTaskInfoWithContext(ctx context.Context, id string) (Task, error) {
+	ctx = withOperation(ctx, "GetTaskInfo", RESOURCE_TASK)
+
+	if id == "" {
+		return Task{}, errors.New("ID is required")
+	}
+
+	var info struct {
+		Item syncItem `json:"item"`
+	}
+	if err := t.postSync(ctx, TASK_INFO_PATH, url.Values{"item_id": {id}}, &info); err != nil {
+		return Task{}, err
+	}
+
+	return info.Item.toTask()
 }
 
-func (t Todoist) GetTasks() ([]Task, error) {
-	return []Task{}, nil
+// GetTasksArgs filters the active tasks returned by GetTasks. When Filter is
+// set, ProjectID, SectionID and Label are ignored by Todoist.
+type GetTasksArgs struct {
+	ProjectID string
+	SectionID string
+	Label     string
+	Filter    string
+	Lang      string
+	IDs       []string
+}
+
+func (args GetTasksArgs) query() url.Values {
+	query := url.Values{}
+
+	if args.ProjectID != "" {
+		query.Set("project_id", args.ProjectID)
+	}
+	if args.SectionID != "" {
+		query.Set("section_id", args.SectionID)
+	}
+	if args.Label != "" {
+		query.Set("label", args.Label)
+	}
+	if args.Filter != "" {
+		query.Set("filter", args.Filter)
+	}
+	if args.Lang != "" {
+		query.Set("lang", args.Lang)
+	}
+	if len(args.IDs) > 0 {
+		query.Set("ids", strings.Join(args.IDs, ","))
+	}
+
+	return query
+}
+
+func (t Todoist) GetTasks(args GetTasksArgs) ([]Task, error) {
+	return t.GetTasksWithContext(context.Background(), args)
+}
+
+func (t Todoist) GetTasksWithContext(ctx context.Context, args GetTasksArgs) ([]Task, error) {
+	ctx = withOperation(ctx, "GetTasks", RESOURCE_TASK)
+
+	path := TASK_PATH
+	if query := args.query(); len(query) > 0 {
+		path = fmt.Sprintf("%s?%s", TASK_PATH, query.Encode())
+	}
+
+	request, err := t.newRequest(ctx, http.MethodGet, path, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	response, err := t.do(request)
+	if err != nil {
+		return nil, err
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode != http.StatusOK {
+		return nil, newAPIError(response)
+	}
+
+	data, err := io.ReadAll(response.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	var tasks []Task
+	if err := json.Unmarshal(data, &tasks); err != nil {
+		return nil, err
+	}
+
+	return tasks, nil
 }
 
 type UpdateTaskArgs struct {
-	Content      string   `json:"content,omitempty"`
-	Description  string   `json:"description,omitempty"`
-	Labels       []string `json:"labels,omitempty"`
-	Priority     uint8    `json:"priority,omitempty"`
-	DueString    string   `json:"due_string,omitempty"`
-	DueDate      string   `json:"due_date,omitempty"`
-	DueDatetime  string   `json:"due_datetime,omitempty"`
-	DueLang      string   `json:"due_lang,omitempty"`
-	AssigneeID   string   `json:"assignee_id,omitempty"`
-	Duration     uint     `json:"duration,omitempty"`
-	DurationUnit string   `json:"duration_unit,omitempty"`
+	Content      string    `json:"content,omitempty"`
+	Description  string    `json:"description,omitempty"`
+	Labels       []string  `json:"labels,omitempty"`
+	Priority     uint8     `json:"priority,omitempty"`
+	DueString    string    `json:"due_string,omitempty"`
+	DueDate      *Date     `json:"due_date,omitempty"`
+	DueDatetime  *DateTime `json:"due_datetime,omitempty"`
+	DueLang      string    `json:"due_lang,omitempty"`
+	AssigneeID   string    `json:"assignee_id,omitempty"`
+	Duration     uint      `json:"duration,omitempty"`
+	DurationUnit string    `json:"duration_unit,omitempty"`
 }
 
 func (t Todoist) UpdateTask(args UpdateTaskArgs, id string) (Task, error) {
+	return t.UpdateTaskWithContext(context.Background(), args, id)
+}
+
+func (t Todoist) UpdateTaskWithContext(ctx context.Context, args UpdateTaskArgs, id string) (Task, error) {
+	ctx = withOperation(ctx, "UpdateTask", RESOURCE_TASK)
+
 	if id == "" {
 		return Task{}, errors.New("ID is required")
 	}
@@ -160,29 +339,23 @@ func (t Todoist) UpdateTask(args UpdateTaskArgs, id string) (Task, error) {
 		return Task{}, err
 	}
 
-	url := fmt.Sprintf("https://api.todoist.com/rest/v2/tasks/%s", id)
+	path := fmt.Sprintf("%s/%s", TASK_PATH, id)
 
-	client := &http.Client{
-		Timeout: MAX_TIMEOUT,
-	}
-
-	request, err := http.NewRequest(http.MethodPost, url, bytes.NewReader(bodyRequest))
+	request, err := t.newRequest(ctx, http.MethodPost, path, bytes.NewReader(bodyRequest))
 	if err != nil {
 		return Task{}, err
 	}
 
-	request.Header.Set("Authorization", fmt.Sprintf("Bearer %s", t.authToken))
-	request.Header.Set("X-Request-Id", pkg.NewUUID())
 	request.Header.Set("Content-Type", "application/json")
 
-	response, err := client.Do(request)
+	response, err := t.do(request)
 	if err != nil {
 		return Task{}, err
 	}
 	defer response.Body.Close()
 
 	if response.StatusCode != http.StatusOK {
-		return Task{}, fmt.Errorf("HTTP request failed with status code: %d", response.StatusCode)
+		return Task{}, newAPIError(response)
 	}
 
 	data, err := io.ReadAll(response.Body)
@@ -217,82 +390,205 @@ func (t Todoist) UpdateTask(args UpdateTaskArgs, id string) (Task, error) {
 }
 
 func (t Todoist) CloseTask(id string) error {
-	url := fmt.Sprintf("https://api.todoist.com/rest/v2/tasks/%s/close", id)
+	return t.CloseTaskWithContext(context.Background(), id)
+}
 
-	client := &http.Client{
-		Timeout: MAX_TIMEOUT,
-	}
+func (t Todoist) CloseTaskWithContext(ctx context.Context, id string) error {
+	ctx = withOperation(ctx, "CloseTask", RESOURCE_TASK)
+
+	path := fmt.Sprintf("%s/%s/close", TASK_PATH, id)
 
-	request, err := http.NewRequest(http.MethodPost, url, nil)
+	request, err := t.newRequest(ctx, http.MethodPost, path, nil)
 	if err != nil {
 		return err
 	}
 
-	request.Header.Set("Authorization", fmt.Sprintf("Bearer %s", t.authToken))
-
-	response, err := client.Do(request)
+	response, err := t.do(request)
 	if err != nil {
 		return err
 	}
 	defer response.Body.Close()
 
 	if response.StatusCode != http.StatusNoContent {
-		return fmt.Errorf("HTTP request failed with status code: %d", response.StatusCode)
+		return newAPIError(response)
 	}
 
 	return nil
 }
 
 func (t Todoist) ReopenTask(id string) error {
-	url := fmt.Sprintf("https://api.todoist.com/rest/v2/tasks/%s/reopen", id)
+	return t.ReopenTaskWithContext(context.Background(), id)
+}
 
-	client := &http.Client{
-		Timeout: MAX_TIMEOUT,
-	}
+func (t Todoist) ReopenTaskWithContext(ctx context.Context, id string) error {
+	ctx = withOperation(ctx, "ReopenTask", RESOURCE_TASK)
+
+	path := fmt.Sprintf("%s/%s/reopen", TASK_PATH, id)
 
-	request, err := http.NewRequest(http.MethodPost, url, nil)
+	request, err := t.newRequest(ctx, http.MethodPost, path, nil)
 	if err != nil {
 		return err
 	}
 
-	request.Header.Set("Authorization", fmt.Sprintf("Bearer %s", t.authToken))
-
-	response, err := client.Do(request)
+	response, err := t.do(request)
 	if err != nil {
 		return err
 	}
 	defer response.Body.Close()
 
 	if response.StatusCode != http.StatusNoContent {
-		return fmt.Errorf("HTTP request failed with status code: %d", response.StatusCode)
+		return newAPIError(response)
 	}
 
 	return nil
 }
 
 func (t Todoist) DeleteTask(id string) error {
-	url := fmt.Sprintf("https://api.todoist.com/rest/v2/tasks/%s", id)
+	return t.DeleteTaskWithContext(context.Background(), id)
+}
 
-	client := &http.Client{
-		Timeout: MAX_TIMEOUT,
-	}
+func (t Todoist) DeleteTaskWithContext(ctx context.Context, id string) error {
+	ctx = withOperation(ctx, "DeleteTask", RESOURCE_TASK)
 
-	request, err := http.NewRequest(http.MethodDelete, url, nil)
+	path := fmt.Sprintf("%s/%s", TASK_PATH, id)
+
+	request, err := t.newRequest(ctx, http.MethodDelete, path, nil)
 	if err != nil {
 		return err
 	}
 
-	request.Header.Set("Authorization", fmt.Sprintf("Bearer %s", t.authToken))
-
-	response, err := client.Do(request)
+	response, err := t.do(request)
 	if err != nil {
 		return err
 	}
 	defer response.Body.Close()
 
 	if response.StatusCode != http.StatusNoContent {
-		return fmt.Errorf("HTTP request failed with status code: %d", response.StatusCode)
+		return newAPIError(response)
 	}
 
 	return nil
 }
+
+// MoveTaskArgs sets where a task is moved. Exactly one field must be set.
+type MoveTaskArgs struct {
+	ProjectID string
+	SectionID string
+	ParentID  string
+}
+
+func (args MoveTaskArgs) validate() error {
+	set := 0
+	for _, id := range []string{args.ProjectID, args.SectionID, args.ParentID} {
+		if id != "" {
+			set++
+		}
+	}
+
+	if set != 1 {
+		return errors.New("exactly one of `ProjectID`, `SectionID` or `ParentID` is required")
+	}
+
+	return nil
+}
+
+func (args MoveTaskArgs) commandArgs(id string) map[string]any {
+	return compactArgs(map[string]any{
+		"id":         id,
+		"project_id": args.ProjectID,
+		"section_id": args.SectionID,
+		"parent_id":  args.ParentID,
+	})
+}
+
+// MoveTask moves a task, along with its subtasks, to another project, section
+// or parent task. Unlike deleting and recreating it, its ID, comments and
+// history are kept.
+func (t Todoist) MoveTask(id string, args MoveTaskArgs) error {
+	return t.MoveTaskWithContext(context.Background(), id, args)
+}
+
+func (t Todoist) MoveTaskWithContext(ctx context.Context, id string, args MoveTaskArgs) error {
+	ctx = withOperation(ctx, "MoveTask", RESOURCE_TASK)
+
+	if id == "" {
+		return errors.New("ID is required")
+	}
+
+	if err := args.validate(); err != nil {
+		return err
+	}
+
+	return t.runCommand(ctx, newSyncCommand("item_move", args.commandArgs(id)))
+}
+
+// MoveTasks moves several tasks to the same place, sending as few requests as
+// possible. Tasks that could not be moved are reported in the returned error,
+// which joins one *CommandError per task.
+func (t Todoist) MoveTasks(ids []string, args MoveTaskArgs) error {
+	return t.MoveTasksWithContext(context.Background(), ids, args)
+}
+
+func (t Todoist) MoveTasksWithContext(ctx context.Context, ids []string, args MoveTaskArgs) error {
+	ctx = withOperation(ctx, "MoveTasks", RESOURCE_TASK)
+
+	if len(ids) == 0 {
+		return errors.New("at least one task ID is required")
+	}
+
+	batch := t.NewBatch()
+	for _, id := range ids {
+		batch.MoveTask(id, args)
+	}
+
+	result, err := batch.CommitWithContext(ctx)
+	if err != nil {
+		return err
+	}
+
+	return result.Err()
+}
+
+// QuickAddTaskArgs holds the text to parse, as typed in Todoist's quick add
+// box, e.g. "Pay rent tomorrow 9am #Home @finance p1".
+type QuickAddTaskArgs struct {
+	Text string
+	// Note is added to the task as a comment.
+	Note string
+	// Reminder is parsed like Text, e.g. "tomorrow 8am".
+	Reminder string
+	// AutoReminder adds the user's default reminder to tasks due at a time.
+	AutoReminder bool
+}
+
+// QuickAddTask creates a task from natural language, letting Todoist resolve
+// its project, section, labels, priority and due date from the text.
+func (t Todoist) QuickAddTask(args QuickAddTaskArgs) (Task, error) {
+	return t.QuickAddTaskWithContext(context.Background(), args)
+}
+
+func (t Todoist) QuickAddTaskWithContext(ctx context.Context, args QuickAddTaskArgs) (Task, error) {
+	ctx = withOperation(ctx, "QuickAddTask", RESOURCE_TASK)
+
+	if args.Text == "" {
+		return Task{}, errors.New("`Text` field is required")
+	}
+
+	form := url.Values{"text": {args.Text}}
+	if args.Note != "" {
+		form.Set("note", args.Note)
+	}
+	if args.Reminder != "" {
+		form.Set("reminder", args.Reminder)
+	}
+	if args.AutoReminder {
+		form.Set("auto_reminder", "true")
+	}
+
+	var item syncItem
+	if err := t.postSync(ctx, QUICK_ADD_PATH, form, &item); err != nil {
+		return Task{}, err
+	}
+
+	return item.toTask()
+}
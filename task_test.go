@@ -0,0 +1,224 @@
+package todoist
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"os"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func loadTasks(t *testing.T) []Task {
+	t.Helper()
+
+	data, err := os.ReadFile("testdata/tasks.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var tasks []Task
+	if err := json.Unmarshal(data, &tasks); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(tasks) != 2 {
+		t.Fatalf("got %d tasks, want 2", len(tasks))
+	}
+
+	return tasks
+}
+
+func TestTaskDecode(t *testing.T) {
+	tasks := loadTasks(t)
+	task := tasks[0]
+
+	if task.ProjectID != "2203306141" {
+		t.Errorf("ProjectID = %q", task.ProjectID)
+	}
+	if task.CommentCount != 10 {
+		t.Errorf("CommentCount = %d", task.CommentCount)
+	}
+	if !task.IsCompleted {
+		t.Error("IsCompleted = false")
+	}
+	if task.Order != 1000 {
+		t.Errorf("Order = %d, want 1000", task.Order)
+	}
+	if !task.Due.IsRecurring {
+		t.Error("Due.IsRecurring = false")
+	}
+	if task.Due.Date != NewDate(2016, time.September, 1) {
+		t.Errorf("Due.Date = %s", task.Due.Date)
+	}
+	if want := time.Date(2016, time.September, 1, 12, 0, 0, 0, time.UTC); !task.Due.Datetime.Equal(want) || task.Due.Datetime.Floating {
+		t.Errorf("Due.Datetime = %s", task.Due.Datetime)
+	}
+	if task.Duration != (TaskDuration{Amount: 15, Unit: "minute"}) {
+		t.Errorf("Duration = %+v", task.Duration)
+	}
+	if want := time.Date(2019, time.December, 11, 22, 36, 50, 0, time.UTC); !task.CreatedAt.Equal(want) {
+		t.Errorf("CreatedAt = %s", task.CreatedAt)
+	}
+}
+
+func TestTaskDecodeNulls(t *testing.T) {
+	task := loadTasks(t)[1]
+
+	if task.Due != (TaskDue{}) {
+		t.Errorf("Due = %+v, want zero value", task.Due)
+	}
+	if task.Duration != (TaskDuration{}) {
+		t.Errorf("Duration = %+v, want zero value", task.Duration)
+	}
+	if task.SectionID != "" || task.ParentID != "" || task.AssigneeID != "" {
+		t.Errorf("null IDs decoded as %q, %q, %q", task.SectionID, task.ParentID, task.AssigneeID)
+	}
+
+	due, err := task.Due.Time()
+	if err != nil || !due.IsZero() {
+		t.Errorf("Due.Time() = %s, %v", due, err)
+	}
+}
+
+func TestTaskRoundTrip(t *testing.T) {
+	for _, task := range loadTasks(t) {
+		data, err := json.Marshal(task)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		var decoded Task
+		if err := json.Unmarshal(data, &decoded); err != nil {
+			t.Fatal(err)
+		}
+
+		if !reflect.DeepEqual(decoded, task) {
+			t.Errorf("task %s changed after round trip:\n got %+v\nwant %+v", task.ID, decoded, task)
+		}
+	}
+}
+
+func TestTaskDueEncode(t *testing.T) {
+	data, err := json.Marshal(TaskDue{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := `{"string":"","date":null,"is_recurring":false,"datetime":null,"timezone":""}`
+	if string(data) != want {
+		t.Errorf("got %s, want %s", data, want)
+	}
+
+	data, err = json.Marshal(TaskDuration{Amount: 300, Unit: "day"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if want := `{"amount":300,"unit":"day"}`; string(data) != want {
+		t.Errorf("got %s, want %s", data, want)
+	}
+}
+
+func TestAddTaskTruncatedBody(t *testing.T) {
+	server := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Length", "100")
+		fmt.Fprint(w, `{"id": "1"`)
+	})
+
+	task, err := server.client().AddTask(AddTaskArgs{Content: "Buy milk"})
+	if !errors.Is(err, io.ErrUnexpectedEOF) {
+		t.Fatalf("err = %v, want %v", err, io.ErrUnexpectedEOF)
+	}
+	if task.ID != "" {
+		t.Errorf("task = %+v, want none", task)
+	}
+}
+
+func TestQuickAddTask(t *testing.T) {
+	fixture, err := os.ReadFile("testdata/quick_add.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	server := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Write(fixture)
+	})
+
+	var logs bytes.Buffer
+	client := server.client(
+		WithLogger(slog.New(slog.NewJSONHandler(&logs, nil))),
+		WithLogOptions(LogOptions{Bodies: true, RedactContent: true}),
+	)
+
+	task, err := client.QuickAddTask(QuickAddTaskArgs{
+		Text: "Pay rent tomorrow 9am #Home @finance p1",
+		Note: "Landlord's IBAN is in the shared folder",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	request := server.Requests()[0]
+	if request.Method != http.MethodPost || request.Path != QUICK_ADD_PATH {
+		t.Errorf("request = %s %s, want POST %s", request.Method, request.Path, QUICK_ADD_PATH)
+	}
+	form, _ := url.ParseQuery(request.Body)
+	if form.Get("text") != "Pay rent tomorrow 9am #Home @finance p1" || form.Get("note") == "" {
+		t.Errorf("form = %v", form)
+	}
+
+	if task.ID != "2995104339" || task.Content != "Pay rent" || task.ProjectID != "2203306141" || task.SectionID != "" {
+		t.Errorf("task = %+v", task)
+	}
+	if !reflect.DeepEqual(task.Labels, []string{"finance"}) {
+		t.Errorf("labels = %v, want [finance]", task.Labels)
+	}
+	if task.Priority != 4 {
+		t.Errorf("priority = %d, want 4", task.Priority)
+	}
+
+	for _, secret := range []string{"Pay rent", "IBAN"} {
+		if strings.Contains(logs.String(), secret) {
+			t.Errorf("logs contain %q: %s", secret, logs.String())
+		}
+	}
+
+	lisbon, err := time.LoadLocation("Europe/Lisbon")
+	if err != nil {
+		t.Skip(err)
+	}
+
+	if task.Due.String != "tomorrow 9am" || task.Due.Date != NewDate(2026, time.October, 18) ||
+		!task.Due.Datetime.Equal(time.Date(2026, time.October, 18, 9, 0, 0, 0, lisbon)) {
+		t.Errorf("due = %+v", task.Due)
+	}
+}
+
+func TestGetTaskInfo(t *testing.T) {
+	server := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"item": {"id": "2995104339", "content": "Pay rent", "checked": true, "due": {"date": "2026-10-18", "string": "tomorrow"}}, "notes": []}`)
+	})
+
+	task, err := server.client().GetTaskInfo("2995104339")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	request := server.Requests()[0]
+	form, _ := url.ParseQuery(request.Body)
+	if request.Method != http.MethodPost || request.Path != TASK_INFO_PATH || form.Get("item_id") != "2995104339" {
+		t.Errorf("request = %s %s %v", request.Method, request.Path, form)
+	}
+
+	if task.ID != "2995104339" || !task.IsCompleted || task.Due.Date != NewDate(2026, time.October, 18) {
+		t.Errorf("task = %+v", task)
+	}
+}
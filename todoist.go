@@ -1,6 +1,15 @@
 package todoist
 
-import "time"
+import (
+	"context"
+	"io"
+	"log/slog"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/felipeornelis/todoist-go-client/pkg"
+)
 
 const (
 	BASE_URL    = "https://api.todoist.com/rest/v2"
@@ -8,11 +17,119 @@ const (
 )
 
 type Todoist struct {
-	authToken string
+	tokenSource TokenSource
+	baseURL     string
+	syncURL     string
+	httpClient  *http.Client
+	userAgent   string
+
+	retryPolicy RetryPolicy
+	rateLimiter *RateLimiter
+	middleware  []Middleware
+
+	instrumentation []Instrumentation
+
+	logger     *slog.Logger
+	logOptions LogOptions
+}
+
+// Option configures a Todoist client. Options are applied in the order they
+// are passed to New.
+type Option func(*Todoist)
+
+func New(authToken string, opts ...Option) Todoist {
+	t := Todoist{
+		tokenSource: StaticToken(authToken),
+		baseURL:     BASE_URL,
+		syncURL:     SYNC_BASE_URL,
+		httpClient: &http.Client{
+			Timeout: MAX_TIMEOUT,
+		},
+	}
+
+	for _, opt := range opts {
+		opt(&t)
+	}
+
+	return t
+}
+
+// WithBaseURL points the client at another REST endpoint, e.g. a local
+// stand-in server used in tests.
+func WithBaseURL(baseURL string) Option {
+	return func(t *Todoist) {
+		t.baseURL = strings.TrimSuffix(baseURL, "/")
+	}
 }
 
-func New(authToken string) Todoist {
-	return Todoist{
-		authToken: authToken,
+// WithHTTPClient makes the client send every request through httpClient.
+func WithHTTPClient(httpClient *http.Client) Option {
+	return func(t *Todoist) {
+		if httpClient != nil {
+			t.httpClient = httpClient
+		}
 	}
 }
+
+// WithTimeout overrides the default MAX_TIMEOUT. The underlying http.Client
+// is copied, so a client given through WithHTTPClient is left untouched.
+func WithTimeout(timeout time.Duration) Option {
+	return func(t *Todoist) {
+		client := *t.httpClient
+		client.Timeout = timeout
+		t.httpClient = &client
+	}
+}
+
+// WithTransport sets the RoundTripper used to send requests. As with
+// WithTimeout, the underlying http.Client is copied.
+func WithTransport(transport http.RoundTripper) Option {
+	return func(t *Todoist) {
+		client := *t.httpClient
+		client.Transport = transport
+		t.httpClient = &client
+	}
+}
+
+// WithUserAgent sets the User-Agent header sent with every request.
+func WithUserAgent(userAgent string) Option {
+	return func(t *Todoist) {
+		t.userAgent = userAgent
+	}
+}
+
+type requestIDKey struct{}
+
+// ContextWithRequestID makes requests sent with ctx carry requestID as their
+// X-Request-Id instead of a fresh one. Todoist uses it as an idempotency key,
+// so replaying a mutation with the same ID does not apply it twice.
+func ContextWithRequestID(ctx context.Context, requestID string) context.Context {
+	return context.WithValue(ctx, requestIDKey{}, requestID)
+}
+
+func (t Todoist) newRequest(ctx context.Context, method, path string, body io.Reader) (*http.Request, error) {
+	return t.newRequestURL(ctx, method, t.baseURL+path, body)
+}
+
+func (t Todoist) newSyncRequest(ctx context.Context, method, path string, body io.Reader) (*http.Request, error) {
+	return t.newRequestURL(ctx, method, t.syncURL+path, body)
+}
+
+func (t Todoist) newRequestURL(ctx context.Context, method, url string, body io.Reader) (*http.Request, error) {
+	request, err := http.NewRequestWithContext(ctx, method, url, body)
+	if err != nil {
+		return nil, err
+	}
+
+	requestID, ok := ctx.Value(requestIDKey{}).(string)
+	if !ok {
+		requestID = pkg.NewUUID()
+	}
+	request.Header.Set("X-Request-Id", requestID)
+
+	if t.userAgent != "" {
+		request.Header.Set("User-Agent", t.userAgent)
+	}
+
+	return request, nil
+}
@@ -0,0 +1,116 @@
+package todoist
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// recordedRequest is a request received by a testServer.
+type recordedRequest struct {
+	Method string
+	Path   string
+	Query  url.Values
+	Header http.Header
+	Body   string
+}
+
+// commands decodes the Sync commands sent in a form-encoded request.
+func (r recordedRequest) commands(t *testing.T) []syncCommand {
+	t.Helper()
+
+	form, err := url.ParseQuery(r.Body)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var commands []syncCommand
+	if err := json.Unmarshal([]byte(form.Get("commands")), &commands); err != nil {
+		t.Fatal(err)
+	}
+
+	return commands
+}
+
+// testServer records every request and answers with handler, which defaults
+// to syncOK.
+type testServer struct {
+	*httptest.Server
+
+	mu       sync.Mutex
+	requests []recordedRequest
+}
+
+func newTestServer(t *testing.T, handler http.HandlerFunc) *testServer {
+	t.Helper()
+
+	if handler == nil {
+		handler = syncOK
+	}
+
+	s := &testServer{}
+	s.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		r.Body = io.NopCloser(strings.NewReader(string(body)))
+
+		s.mu.Lock()
+		s.requests = append(s.requests, recordedRequest{
+			Method: r.Method,
+			Path:   r.URL.Path,
+			Query:  r.URL.Query(),
+			Header: r.Header.Clone(),
+			Body:   string(body),
+		})
+		s.mu.Unlock()
+
+		handler(w, r)
+	}))
+	t.Cleanup(s.Close)
+
+	return s
+}
+
+func (s *testServer) client(opts ...Option) Todoist {
+	return New("test-token", append([]Option{WithBaseURL(s.URL), WithSyncURL(s.URL)}, opts...)...)
+}
+
+func (s *testServer) Requests() []recordedRequest {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return append([]recordedRequest(nil), s.requests...)
+}
+
+// syncOK answers Sync command requests with every command succeeding and
+// each temporary ID mapped to "id-<temp ID>", and other requests with an
+// empty JSON object.
+func syncOK(w http.ResponseWriter, r *http.Request) {
+	if err := r.ParseForm(); err != nil || r.PostForm.Get("commands") == "" {
+		fmt.Fprint(w, "{}")
+		return
+	}
+
+	var commands []syncCommand
+	json.Unmarshal([]byte(r.PostForm.Get("commands")), &commands)
+
+	status := map[string]string{}
+	mapping := map[string]string{}
+	for _, command := range commands {
+		status[command.UUID] = "ok"
+		if command.TempID != "" {
+			mapping[command.TempID] = "id-" + command.TempID
+		}
+	}
+
+	json.NewEncoder(w).Encode(map[string]any{
+		"sync_token":      "token",
+		"sync_status":     status,
+		"temp_id_mapping": mapping,
+	})
+}
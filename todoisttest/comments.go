@@ -0,0 +1,141 @@
+package todoisttest
+
+import (
+	"net/http"
+	"time"
+
+	todoist "github.com/felipeornelis/todoist-go-client"
+)
+
+func (s *Server) serveComments(w http.ResponseWriter, r *http.Request, parts []string) {
+	switch {
+	case len(parts) == 0 && r.Method == http.MethodGet:
+		s.listComments(w, r)
+	case len(parts) == 0 && r.Method == http.MethodPost:
+		s.addComment(w, r)
+	case len(parts) == 1 && r.Method == http.MethodGet:
+		if comment, ok := s.findComment(w, parts[0]); ok {
+			writeJSON(w, comment)
+		}
+	case len(parts) == 1 && r.Method == http.MethodPost:
+		s.updateComment(w, r, parts[0])
+	case len(parts) == 1 && r.Method == http.MethodDelete:
+		if comment, ok := s.findComment(w, parts[0]); ok {
+			delete(s.comments, comment.ID)
+			s.countComment(comment, -1)
+			w.WriteHeader(http.StatusNoContent)
+		}
+	case len(parts) == 1:
+		writeMethodNotAllowed(w)
+	default:
+		writeError(w, http.StatusNotFound, "Not found")
+	}
+}
+
+func (s *Server) findComment(w http.ResponseWriter, id string) (*todoist.Comment, bool) {
+	comment, ok := s.comments[id]
+	if !ok {
+		writeError(w, http.StatusNotFound, "Comment not found")
+	}
+
+	return comment, ok
+}
+
+func (s *Server) listComments(w http.ResponseWriter, r *http.Request) {
+	taskID := r.URL.Query().Get("task_id")
+	projectID := r.URL.Query().Get("project_id")
+
+	if taskID == "" && projectID == "" {
+		writeMissingArgument(w, "task_id or project_id")
+		return
+	}
+
+	comments := []todoist.Comment{}
+	for _, id := range sortedIDs(s.comments) {
+		comment := s.comments[id]
+		if (taskID != "" && comment.TaskID == taskID) || (projectID != "" && comment.ProjectID == projectID) {
+			comments = append(comments, *comment)
+		}
+	}
+
+	writeJSON(w, comments)
+}
+
+func (s *Server) addComment(w http.ResponseWriter, r *http.Request) {
+	var args todoist.AddCommentArgs
+	if !decodeBody(w, r, &args) {
+		return
+	}
+
+	if args.TaskID == "" && args.ProjectID == "" {
+		writeMissingArgument(w, "task_id or project_id")
+		return
+	}
+
+	if args.TaskID != "" && args.ProjectID != "" {
+		writeError(w, http.StatusBadRequest, "Only one of task_id or project_id can be set")
+		return
+	}
+
+	if args.Content == "" && args.Attachment == (todoist.CommentAttachment{}) {
+		writeMissingArgument(w, "content")
+		return
+	}
+
+	if _, ok := s.tasks[args.TaskID]; args.TaskID != "" && !ok {
+		writeError(w, http.StatusBadRequest, "Task not found")
+		return
+	}
+
+	if _, ok := s.projects[args.ProjectID]; args.ProjectID != "" && !ok {
+		writeError(w, http.StatusBadRequest, "Project not found")
+		return
+	}
+
+	comment := &todoist.Comment{
+		ID:         s.nextID(),
+		TaskID:     args.TaskID,
+		ProjectID:  args.ProjectID,
+		PostedAt:   todoist.NewDateTime(time.Now().UTC()),
+		Content:    args.Content,
+		Attachment: args.Attachment,
+	}
+
+	s.comments[comment.ID] = comment
+	s.countComment(comment, 1)
+
+	writeJSON(w, comment)
+}
+
+func (s *Server) updateComment(w http.ResponseWriter, r *http.Request, id string) {
+	comment, ok := s.findComment(w, id)
+	if !ok {
+		return
+	}
+
+	var args todoist.UpdateCommentArgs
+	if !decodeBody(w, r, &args) {
+		return
+	}
+
+	if args.Content == "" {
+		writeMissingArgument(w, "content")
+		return
+	}
+
+	comment.Content = args.Content
+
+	writeJSON(w, comment)
+}
+
+// countComment keeps the comment_count of the commented resource up to date.
+// It must be called with s.mu held.
+func (s *Server) countComment(comment *todoist.Comment, delta int) {
+	if task, ok := s.tasks[comment.TaskID]; ok {
+		task.CommentCount += delta
+	}
+
+	if project, ok := s.projects[comment.ProjectID]; ok {
+		project.CommentCount += delta
+	}
+}
@@ -0,0 +1,45 @@
+package todoisttest
+
+import (
+	"context"
+	"sync"
+
+	todoist "github.com/felipeornelis/todoist-go-client"
+)
+
+// CallRecorder is a todoist.Instrumentation keeping every finished call in
+// memory, so tests can assert on the calls made by the code under test.
+type CallRecorder struct {
+	mu    sync.Mutex
+	calls []todoist.CallResult
+}
+
+func (r *CallRecorder) StartCall(ctx context.Context, call todoist.Call) (context.Context, func(todoist.CallResult)) {
+	return ctx, func(result todoist.CallResult) {
+		r.mu.Lock()
+		defer r.mu.Unlock()
+
+		r.calls = append(r.calls, result)
+	}
+}
+
+// Calls returns the finished calls, oldest first.
+func (r *CallRecorder) Calls() []todoist.CallResult {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return append([]todoist.CallResult(nil), r.calls...)
+}
+
+// Operations returns the operation names of the finished calls, oldest first.
+func (r *CallRecorder) Operations() []string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	operations := make([]string, len(r.calls))
+	for i, call := range r.calls {
+		operations[i] = call.Operation
+	}
+
+	return operations
+}
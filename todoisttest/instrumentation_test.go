@@ -0,0 +1,68 @@
+package todoisttest
+
+import (
+	"net/http"
+	"reflect"
+	"testing"
+
+	todoist "github.com/felipeornelis/todoist-go-client"
+)
+
+func TestCallRecorder(t *testing.T) {
+	server := NewServer()
+	defer server.Close()
+
+	recorder := &CallRecorder{}
+	metrics := todoist.NewMetrics()
+	client := server.Client(todoist.WithInstrumentation(metrics, recorder))
+
+	first, err := client.AddTask(todoist.AddTaskArgs{Content: "Buy milk"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	second, err := client.AddTask(todoist.AddTaskArgs{Content: "Buy bread"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, id := range []string{first.ID, second.ID, "missing"} {
+		client.GetTask(id)
+	}
+	if err := client.MoveTasks([]string{first.ID, second.ID}, todoist.MoveTaskArgs{ProjectID: server.InboxID()}); err != nil {
+		t.Fatal(err)
+	}
+
+	want := []string{"AddTask", "AddTask", "GetTask", "GetTask", "GetTask", "MoveTasks"}
+	if got := recorder.Operations(); !reflect.DeepEqual(got, want) {
+		t.Fatalf("operations = %v, want %v", got, want)
+	}
+
+	calls := recorder.Calls()
+	if call := calls[2]; call.Resource != todoist.RESOURCE_TASK || call.Method != http.MethodGet ||
+		call.Endpoint != "/rest/v2/tasks/"+first.ID || call.Route != "/rest/v2/tasks/{id}" ||
+		call.StatusCode != http.StatusOK || call.Attempts != 1 || call.RequestID == "" {
+		t.Errorf("GetTask call = %+v", call)
+	}
+	if call := calls[4]; call.StatusCode != http.StatusNotFound {
+		t.Errorf("missing task status = %d, want 404", call.StatusCode)
+	}
+	if call := calls[5]; call.Resource != todoist.RESOURCE_TASK || call.Route != "/sync/v9/sync" {
+		t.Errorf("MoveTasks call = %+v", call)
+	}
+
+	type row struct {
+		Operation, Method, Endpoint string
+		Calls, Errors               int
+	}
+	var got []row
+	for _, m := range metrics.Snapshot() {
+		got = append(got, row{m.Operation, m.Method, m.Endpoint, m.Calls, m.Errors})
+	}
+	wantRows := []row{
+		{"AddTask", http.MethodPost, "/rest/v2/tasks", 2, 0},
+		{"GetTask", http.MethodGet, "/rest/v2/tasks/{id}", 3, 1},
+		{"MoveTasks", http.MethodPost, "/sync/v9/sync", 1, 0},
+	}
+	if !reflect.DeepEqual(got, wantRows) {
+		t.Errorf("metrics = %+v, want %+v", got, wantRows)
+	}
+}
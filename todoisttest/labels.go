@@ -0,0 +1,203 @@
+package todoisttest
+
+import (
+	"net/http"
+	"sort"
+
+	todoist "github.com/felipeornelis/todoist-go-client"
+)
+
+func (s *Server) serveLabels(w http.ResponseWriter, r *http.Request, parts []string) {
+	switch {
+	case len(parts) == 0 && r.Method == http.MethodGet:
+		labels := []todoist.Label{}
+		for _, id := range sortedIDs(s.labels) {
+			labels = append(labels, *s.labels[id])
+		}
+		writeJSON(w, labels)
+	case len(parts) == 0 && r.Method == http.MethodPost:
+		s.addLabel(w, r)
+	case len(parts) == 1 && parts[0] == "shared" && r.Method == http.MethodGet:
+		s.listSharedLabels(w, r)
+	case len(parts) == 2 && parts[0] == "shared" && r.Method == http.MethodPost && parts[1] == "rename":
+		s.renameSharedLabel(w, r)
+	case len(parts) == 2 && parts[0] == "shared" && r.Method == http.MethodPost && parts[1] == "remove":
+		s.removeSharedLabel(w, r)
+	case len(parts) == 1 && r.Method == http.MethodGet:
+		if label, ok := s.findLabel(w, parts[0]); ok {
+			writeJSON(w, label)
+		}
+	case len(parts) == 1 && r.Method == http.MethodPost:
+		s.updateLabel(w, r, parts[0])
+	case len(parts) == 1 && r.Method == http.MethodDelete:
+		if label, ok := s.findLabel(w, parts[0]); ok {
+			delete(s.labels, label.ID)
+			s.replaceTaskLabel(label.Name, "")
+			w.WriteHeader(http.StatusNoContent)
+		}
+	case len(parts) <= 2:
+		writeMethodNotAllowed(w)
+	default:
+		writeError(w, http.StatusNotFound, "Not found")
+	}
+}
+
+func (s *Server) findLabel(w http.ResponseWriter, id string) (*todoist.Label, bool) {
+	label, ok := s.labels[id]
+	if !ok {
+		writeError(w, http.StatusNotFound, "Label not found")
+	}
+
+	return label, ok
+}
+
+func (s *Server) labelNamed(name string) *todoist.Label {
+	for _, label := range s.labels {
+		if label.Name == name {
+			return label
+		}
+	}
+
+	return nil
+}
+
+func (s *Server) addLabel(w http.ResponseWriter, r *http.Request) {
+	var args todoist.AddPersonalLabelArgs
+	if !decodeBody(w, r, &args) {
+		return
+	}
+
+	if args.Name == "" {
+		writeMissingArgument(w, "name")
+		return
+	}
+
+	if s.labelNamed(args.Name) != nil {
+		writeError(w, http.StatusBadRequest, "Label already exists")
+		return
+	}
+
+	label := &todoist.Label{
+		ID:         s.nextID(),
+		Name:       args.Name,
+		Color:      args.Color,
+		Order:      args.Order,
+		IsFavorite: args.IsFavorite,
+	}
+
+	if label.Color == "" {
+		label.Color = "charcoal"
+	}
+
+	s.labels[label.ID] = label
+
+	writeJSON(w, label)
+}
+
+func (s *Server) updateLabel(w http.ResponseWriter, r *http.Request, id string) {
+	label, ok := s.findLabel(w, id)
+	if !ok {
+		return
+	}
+
+	var args todoist.UpdatePersonalLabelArgs
+	if !decodeBody(w, r, &args) {
+		return
+	}
+
+	if args.Name != "" && args.Name != label.Name {
+		if s.labelNamed(args.Name) != nil {
+			writeError(w, http.StatusBadRequest, "Label already exists")
+			return
+		}
+		s.replaceTaskLabel(label.Name, args.Name)
+		label.Name = args.Name
+	}
+	if args.Color != "" {
+		label.Color = args.Color
+	}
+	if args.Order != 0 {
+		label.Order = args.Order
+	}
+	if args.IsFavorite {
+		label.IsFavorite = true
+	}
+
+	writeJSON(w, label)
+}
+
+// listSharedLabels returns every label name used by a task. Like the REST
+// API, it reads omit_personal from the query string only.
+func (s *Server) listSharedLabels(w http.ResponseWriter, r *http.Request) {
+	omitPersonal := r.URL.Query().Get("omit_personal") == "true"
+
+	seen := map[string]bool{}
+	names := []string{}
+	for _, task := range s.tasks {
+		for _, name := range task.Labels {
+			if seen[name] || (omitPersonal && s.labelNamed(name) != nil) {
+				continue
+			}
+			seen[name] = true
+			names = append(names, name)
+		}
+	}
+
+	sort.Strings(names)
+
+	writeJSON(w, names)
+}
+
+func (s *Server) renameSharedLabel(w http.ResponseWriter, r *http.Request) {
+	var args todoist.RenameSharedLabelsArgs
+	if !decodeBody(w, r, &args) {
+		return
+	}
+
+	if args.Name == "" {
+		writeMissingArgument(w, "name")
+		return
+	}
+
+	if args.NewName == "" {
+		writeMissingArgument(w, "new_name")
+		return
+	}
+
+	s.replaceTaskLabel(args.Name, args.NewName)
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (s *Server) removeSharedLabel(w http.ResponseWriter, r *http.Request) {
+	var args todoist.RemoveSharedLabelsArgs
+	if !decodeBody(w, r, &args) {
+		return
+	}
+
+	if args.Name == "" {
+		writeMissingArgument(w, "name")
+		return
+	}
+
+	s.replaceTaskLabel(args.Name, "")
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// replaceTaskLabel renames a label on every task, or removes it when newName
+// is empty. It must be called with s.mu held.
+func (s *Server) replaceTaskLabel(name, newName string) {
+	for _, task := range s.tasks {
+		labels := task.Labels[:0]
+		for _, label := range task.Labels {
+			switch {
+			case label != name:
+				labels = append(labels, label)
+			case newName != "" && !contains(task.Labels, newName):
+				labels = append(labels, newName)
+			}
+		}
+		task.Labels = labels
+	}
+}
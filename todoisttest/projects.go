@@ -0,0 +1,181 @@
+package todoisttest
+
+import (
+	"net/http"
+
+	todoist "github.com/felipeornelis/todoist-go-client"
+)
+
+var viewStyles = map[string]bool{"list": true, "board": true}
+
+func (s *Server) serveProjects(w http.ResponseWriter, r *http.Request, parts []string) {
+	switch {
+	case len(parts) == 0 && r.Method == http.MethodGet:
+		s.listProjects(w)
+	case len(parts) == 0 && r.Method == http.MethodPost:
+		s.addProject(w, r)
+	case len(parts) == 1 && r.Method == http.MethodGet:
+		if project, ok := s.findProject(w, parts[0]); ok {
+			writeJSON(w, project)
+		}
+	case len(parts) == 1 && r.Method == http.MethodPost:
+		s.updateProject(w, r, parts[0])
+	case len(parts) == 1 && r.Method == http.MethodDelete:
+		s.deleteProject(w, parts[0])
+	case len(parts) == 2 && r.Method == http.MethodGet && parts[1] == "collaborators":
+		if _, ok := s.findProject(w, parts[0]); ok {
+			collaborators := s.collaborators[parts[0]]
+			if collaborators == nil {
+				collaborators = []todoist.GetAllCollaboratorsOutput{}
+			}
+			writeJSON(w, collaborators)
+		}
+	case len(parts) <= 2:
+		writeMethodNotAllowed(w)
+	default:
+		writeError(w, http.StatusNotFound, "Not found")
+	}
+}
+
+func (s *Server) findProject(w http.ResponseWriter, id string) (*todoist.Project, bool) {
+	project, ok := s.projects[id]
+	if !ok {
+		writeError(w, http.StatusNotFound, "Project not found")
+	}
+
+	return project, ok
+}
+
+func (s *Server) listProjects(w http.ResponseWriter) {
+	projects := []todoist.Project{}
+	for _, id := range sortedIDs(s.projects) {
+		if project := s.projects[id]; !project.IsArchived {
+			projects = append(projects, *project)
+		}
+	}
+
+	writeJSON(w, projects)
+}
+
+func (s *Server) addProject(w http.ResponseWriter, r *http.Request) {
+	var args todoist.AddProjectArgs
+	if !decodeBody(w, r, &args) {
+		return
+	}
+
+	if args.Name == "" {
+		writeMissingArgument(w, "name")
+		return
+	}
+
+	if args.ParentID != "" {
+		if _, ok := s.projects[args.ParentID]; !ok {
+			writeError(w, http.StatusBadRequest, "Parent project not found")
+			return
+		}
+	}
+
+	project := &todoist.Project{
+		Name:       args.Name,
+		ParentID:   args.ParentID,
+		Color:      args.Color,
+		IsFavorite: args.IsFavorite,
+		ViewStyle:  args.ViewStyle,
+	}
+
+	if project.Color == "" {
+		project.Color = "charcoal"
+	}
+
+	if project.ViewStyle == "" {
+		project.ViewStyle = "list"
+	} else if !viewStyles[project.ViewStyle] {
+		writeError(w, http.StatusBadRequest, "Invalid argument value: view_style")
+		return
+	}
+
+	project.ID = s.nextID()
+	project.URL = projectURL(project.ID)
+	s.projects[project.ID] = project
+
+	writeJSON(w, project)
+}
+
+func (s *Server) updateProject(w http.ResponseWriter, r *http.Request, id string) {
+	project, ok := s.findProject(w, id)
+	if !ok {
+		return
+	}
+
+	var args todoist.UpdateProjectArgs
+	if !decodeBody(w, r, &args) {
+		return
+	}
+
+	if args.ViewStyle != "" && !viewStyles[args.ViewStyle] {
+		writeError(w, http.StatusBadRequest, "Invalid argument value: view_style")
+		return
+	}
+
+	if args.Name != "" {
+		project.Name = args.Name
+	}
+	if args.Color != "" {
+		project.Color = args.Color
+	}
+	if args.IsFavorite {
+		project.IsFavorite = true
+	}
+	if args.ViewStyle != "" {
+		project.ViewStyle = args.ViewStyle
+	}
+
+	writeJSON(w, project)
+}
+
+func (s *Server) deleteProject(w http.ResponseWriter, id string) {
+	project, ok := s.findProject(w, id)
+	if !ok {
+		return
+	}
+
+	if project.IsInboxProject {
+		writeError(w, http.StatusBadRequest, "The Inbox project cannot be deleted")
+		return
+	}
+
+	s.removeProject(id)
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// removeProject deletes the project along with its subprojects, sections,
+// tasks and comments. It must be called with s.mu held.
+func (s *Server) removeProject(id string) {
+	delete(s.projects, id)
+	delete(s.collaborators, id)
+
+	for childID, child := range s.projects {
+		if child.ParentID == id {
+			s.removeProject(childID)
+		}
+	}
+
+	for sectionID, section := range s.sections {
+		if section.ProjectID == id {
+			delete(s.sections, sectionID)
+		}
+	}
+
+	for taskID, task := range s.tasks {
+		if task.ProjectID == id {
+			s.deleteTask(taskID)
+		}
+	}
+
+	for commentID, comment := range s.comments {
+		if comment.ProjectID == id {
+			delete(s.comments, commentID)
+		}
+	}
+}
@@ -0,0 +1,130 @@
+package todoisttest
+
+import (
+	"net/http"
+
+	todoist "github.com/felipeornelis/todoist-go-client"
+)
+
+func (s *Server) serveSections(w http.ResponseWriter, r *http.Request, parts []string) {
+	switch {
+	case len(parts) == 0 && r.Method == http.MethodGet:
+		s.listSections(w, r)
+	case len(parts) == 0 && r.Method == http.MethodPost:
+		s.addSection(w, r)
+	case len(parts) == 1 && r.Method == http.MethodGet:
+		if section, ok := s.findSection(w, parts[0]); ok {
+			writeJSON(w, section)
+		}
+	case len(parts) == 1 && r.Method == http.MethodPost:
+		s.updateSection(w, r, parts[0])
+	case len(parts) == 1 && r.Method == http.MethodDelete:
+		if _, ok := s.findSection(w, parts[0]); ok {
+			s.removeSection(parts[0])
+			w.WriteHeader(http.StatusNoContent)
+		}
+	case len(parts) == 1:
+		writeMethodNotAllowed(w)
+	default:
+		writeError(w, http.StatusNotFound, "Not found")
+	}
+}
+
+func (s *Server) findSection(w http.ResponseWriter, id string) (*todoist.Section, bool) {
+	section, ok := s.sections[id]
+	if !ok {
+		writeError(w, http.StatusNotFound, "Section not found")
+	}
+
+	return section, ok
+}
+
+func (s *Server) listSections(w http.ResponseWriter, r *http.Request) {
+	projectID := r.URL.Query().Get("project_id")
+
+	sections := []todoist.Section{}
+	for _, id := range sortedIDs(s.sections) {
+		if section := s.sections[id]; projectID == "" || section.ProjectID == projectID {
+			sections = append(sections, *section)
+		}
+	}
+
+	writeJSON(w, sections)
+}
+
+func (s *Server) addSection(w http.ResponseWriter, r *http.Request) {
+	var args todoist.AddSectionArgs
+	if !decodeBody(w, r, &args) {
+		return
+	}
+
+	if args.Name == "" {
+		writeMissingArgument(w, "name")
+		return
+	}
+
+	if args.ProjectID == "" {
+		writeMissingArgument(w, "project_id")
+		return
+	}
+
+	if _, ok := s.projects[args.ProjectID]; !ok {
+		writeError(w, http.StatusBadRequest, "Project not found")
+		return
+	}
+
+	section := &todoist.Section{
+		ID:        s.nextID(),
+		ProjectID: args.ProjectID,
+		Order:     int(args.Order),
+		Name:      args.Name,
+	}
+
+	if section.Order == 0 {
+		for _, other := range s.sections {
+			if other.ProjectID == section.ProjectID && other.Order >= section.Order {
+				section.Order = other.Order + 1
+			}
+		}
+		if section.Order == 0 {
+			section.Order = 1
+		}
+	}
+
+	s.sections[section.ID] = section
+
+	writeJSON(w, section)
+}
+
+func (s *Server) updateSection(w http.ResponseWriter, r *http.Request, id string) {
+	section, ok := s.findSection(w, id)
+	if !ok {
+		return
+	}
+
+	var args todoist.UpdateSectionArgs
+	if !decodeBody(w, r, &args) {
+		return
+	}
+
+	if args.Name == "" {
+		writeMissingArgument(w, "name")
+		return
+	}
+
+	section.Name = args.Name
+
+	writeJSON(w, section)
+}
+
+// removeSection deletes the section and its tasks. It must be called with
+// s.mu held.
+func (s *Server) removeSection(id string) {
+	delete(s.sections, id)
+
+	for taskID, task := range s.tasks {
+		if task.SectionID == id {
+			s.deleteTask(taskID)
+		}
+	}
+}
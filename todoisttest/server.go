@@ -0,0 +1,220 @@
+// Package todoisttest provides an in-memory stand-in for the Todoist REST API,
+// so code depending on the client can be tested without network access.
+package todoisttest
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	todoist "github.com/felipeornelis/todoist-go-client"
+)
+
+const (
+	DEFAULT_TOKEN = "todoisttest-token"
+	REST_PREFIX   = "/rest/v2"
+	SYNC_PREFIX   = "/sync/v9"
+
+	firstID = 2995104339
+)
+
+// Server is an httptest.Server answering like the Todoist REST API. Its state
+// lives in memory and is safe for concurrent use.
+type Server struct {
+	*httptest.Server
+
+	// Token is the bearer token requests must carry. It defaults to
+	// DEFAULT_TOKEN.
+	Token string
+
+	mu            sync.Mutex
+	lastID        int64
+	tasks         map[string]*todoist.Task
+	projects      map[string]*todoist.Project
+	sections      map[string]*todoist.Section
+	labels        map[string]*todoist.Label
+	comments      map[string]*todoist.Comment
+	collaborators map[string][]todoist.GetAllCollaboratorsOutput
+}
+
+// NewServer starts a Server with an empty account holding only an Inbox
+// project. Callers must Close it when done.
+func NewServer() *Server {
+	s := &Server{
+		Token:         DEFAULT_TOKEN,
+		lastID:        firstID,
+		tasks:         map[string]*todoist.Task{},
+		projects:      map[string]*todoist.Project{},
+		sections:      map[string]*todoist.Section{},
+		labels:        map[string]*todoist.Label{},
+		comments:      map[string]*todoist.Comment{},
+		collaborators: map[string][]todoist.GetAllCollaboratorsOutput{},
+	}
+
+	inbox := &todoist.Project{
+		ID:             s.nextID(),
+		Name:           "Inbox",
+		Color:          "grey",
+		IsInboxProject: true,
+		ViewStyle:      "list",
+	}
+	inbox.URL = projectURL(inbox.ID)
+	s.projects[inbox.ID] = inbox
+
+	s.Server = httptest.NewServer(s)
+
+	return s
+}
+
+// BaseURL is the REST endpoint to give to todoist.WithBaseURL.
+func (s *Server) BaseURL() string {
+	return s.URL + REST_PREFIX
+}
+
+// SyncURL is the Sync API endpoint to give to todoist.WithSyncURL.
+func (s *Server) SyncURL() string {
+	return s.URL + SYNC_PREFIX
+}
+
+// Client returns a client authenticated against the server, for both the
+// REST and the Sync API. Extra options are applied after the ones pointing it
+// at the server.
+func (s *Server) Client(opts ...todoist.Option) todoist.Todoist {
+	opts = append([]todoist.Option{
+		todoist.WithBaseURL(s.BaseURL()),
+		todoist.WithSyncURL(s.SyncURL()),
+		todoist.WithHTTPClient(s.Server.Client()),
+	}, opts...)
+
+	return todoist.New(s.Token, opts...)
+}
+
+// InboxID returns the ID of the account's Inbox project.
+func (s *Server) InboxID() string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, project := range s.projects {
+		if project.IsInboxProject {
+			return project.ID
+		}
+	}
+
+	return ""
+}
+
+// AddCollaborator shares the project with a collaborator, as listed by
+// GetAllCollaborators.
+func (s *Server) AddCollaborator(projectID string, collaborator todoist.GetAllCollaboratorsOutput) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if collaborator.ID == "" {
+		collaborator.ID = s.nextID()
+	}
+
+	s.collaborators[projectID] = append(s.collaborators[projectID], collaborator)
+
+	if project, ok := s.projects[projectID]; ok {
+		project.IsShared = true
+	}
+}
+
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Header.Get("Authorization") != "Bearer "+s.Token {
+		writeError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if strings.HasPrefix(r.URL.Path, SYNC_PREFIX+"/") {
+		s.serveSync(w, r, strings.TrimPrefix(r.URL.Path, SYNC_PREFIX))
+		return
+	}
+
+	if !strings.HasPrefix(r.URL.Path, REST_PREFIX+"/") {
+		writeError(w, http.StatusNotFound, "Not found")
+		return
+	}
+
+	parts := strings.Split(strings.Trim(strings.TrimPrefix(r.URL.Path, REST_PREFIX), "/"), "/")
+
+	switch parts[0] {
+	case "tasks":
+		s.serveTasks(w, r, parts[1:])
+	case "projects":
+		s.serveProjects(w, r, parts[1:])
+	case "sections":
+		s.serveSections(w, r, parts[1:])
+	case "labels":
+		s.serveLabels(w, r, parts[1:])
+	case "comments":
+		s.serveComments(w, r, parts[1:])
+	default:
+		writeError(w, http.StatusNotFound, "Not found")
+	}
+}
+
+// nextID must be called with s.mu held.
+func (s *Server) nextID() string {
+	s.lastID++
+	return strconv.FormatInt(s.lastID, 10)
+}
+
+func writeJSON(w http.ResponseWriter, value any) {
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(value)
+}
+
+func writeError(w http.ResponseWriter, status int, message string) {
+	http.Error(w, message, status)
+}
+
+func writeMethodNotAllowed(w http.ResponseWriter) {
+	writeError(w, http.StatusMethodNotAllowed, "Method not allowed")
+}
+
+func writeMissingArgument(w http.ResponseWriter, argument string) {
+	writeError(w, http.StatusBadRequest, fmt.Sprintf("Required argument is missing: %s", argument))
+}
+
+func decodeBody(w http.ResponseWriter, r *http.Request, value any) bool {
+	if err := json.NewDecoder(r.Body).Decode(value); err != nil {
+		writeError(w, http.StatusBadRequest, fmt.Sprintf("Invalid JSON body: %s", err))
+		return false
+	}
+
+	return true
+}
+
+// sortedIDs returns the keys of a resource map in creation order.
+func sortedIDs[T any](resources map[string]*T) []string {
+	ids := make([]string, 0, len(resources))
+	for id := range resources {
+		ids = append(ids, id)
+	}
+
+	sort.Slice(ids, func(i, j int) bool {
+		if len(ids[i]) != len(ids[j]) {
+			return len(ids[i]) < len(ids[j])
+		}
+		return ids[i] < ids[j]
+	})
+
+	return ids
+}
+
+func projectURL(id string) string {
+	return fmt.Sprintf("https://todoist.com/showProject?id=%s", id)
+}
+
+func taskURL(id string) string {
+	return fmt.Sprintf("https://todoist.com/showTask?id=%s", id)
+}
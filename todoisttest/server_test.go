@@ -0,0 +1,228 @@
+package todoisttest
+
+import (
+	"errors"
+	"net/http"
+	"reflect"
+	"testing"
+
+	todoist "github.com/felipeornelis/todoist-go-client"
+)
+
+func TestRESTRoundTrip(t *testing.T) {
+	server := NewServer()
+	defer server.Close()
+
+	client := server.Client()
+
+	project, err := client.AddProject(todoist.AddProjectArgs{Name: "Reports"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	task, err := client.AddTask(todoist.AddTaskArgs{Content: "Weekly report", ProjectID: project.ID, Labels: []string{"work"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tasks, err := client.GetTasks(todoist.GetTasksArgs{ProjectID: project.ID})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(tasks) != 1 || tasks[0].ID != task.ID {
+		t.Fatalf("got %+v, want the added task", tasks)
+	}
+
+	if err := client.CloseTask(task.ID); err != nil {
+		t.Fatal(err)
+	}
+	if tasks, _ := client.GetTasks(todoist.GetTasksArgs{ProjectID: project.ID}); len(tasks) != 0 {
+		t.Errorf("completed task still listed: %+v", tasks)
+	}
+
+	_, err = client.GetTask("404")
+	if !errors.Is(err, todoist.ErrNotFound) {
+		t.Errorf("got %v, want ErrNotFound", err)
+	}
+
+	_, err = server.Client(todoist.WithTokenSource(todoist.StaticToken("wrong"))).GetProjects()
+	if !errors.Is(err, todoist.ErrUnauthorized) {
+		t.Errorf("got %v, want ErrUnauthorized", err)
+	}
+}
+
+func TestSharedLabelsQuery(t *testing.T) {
+	server := NewServer()
+	defer server.Close()
+
+	client := server.Client()
+
+	if _, err := client.AddPersonalLabel(todoist.AddPersonalLabelArgs{Name: "personal"}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := client.AddTask(todoist.AddTaskArgs{Content: "Task", Labels: []string{"personal", "shared"}}); err != nil {
+		t.Fatal(err)
+	}
+
+	all, err := client.GetSharedLabels(todoist.GetSharedLabelsArgs{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := []string{"personal", "shared"}; !reflect.DeepEqual(all, want) {
+		t.Errorf("got %v, want %v", all, want)
+	}
+
+	shared, err := client.GetSharedLabels(todoist.GetSharedLabelsArgs{OmitPersonal: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := []string{"shared"}; !reflect.DeepEqual(shared, want) {
+		t.Errorf("got %v, want %v", shared, want)
+	}
+}
+
+func TestSyncCommands(t *testing.T) {
+	server := NewServer()
+	defer server.Close()
+
+	client := server.Client()
+
+	batch := client.NewBatch()
+	projectID := batch.AddProject(todoist.AddProjectArgs{Name: "Moving"})
+	sectionID := batch.AddSection(todoist.AddSectionArgs{Name: "Kitchen", ProjectID: projectID})
+	parentID := batch.AddTask(todoist.AddTaskArgs{Content: "Pack", ProjectID: projectID})
+	childID := batch.AddTask(todoist.AddTaskArgs{Content: "Plates", ParentID: parentID, Priority: 4})
+
+	result, err := batch.Commit()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := result.Err(); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := client.MoveTask(result.ID(parentID), todoist.MoveTaskArgs{SectionID: result.ID(sectionID)}); err != nil {
+		t.Fatal(err)
+	}
+
+	child, err := client.GetTask(result.ID(childID))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if child.SectionID != result.ID(sectionID) || child.ProjectID != result.ID(projectID) || child.Priority != 4 {
+		t.Errorf("subtask not moved with its parent: %+v", child)
+	}
+
+	inbox := server.InboxID()
+	if err := client.MoveSection(result.ID(sectionID), inbox); err != nil {
+		t.Fatal(err)
+	}
+	if child, _ := client.GetTask(result.ID(childID)); child.ProjectID != inbox {
+		t.Errorf("task not moved with its section: %+v", child)
+	}
+
+	if err := client.ArchiveProject(result.ID(projectID)); err != nil {
+		t.Fatal(err)
+	}
+	archived, err := client.GetArchivedProjects()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(archived) != 1 || archived[0].ID != result.ID(projectID) {
+		t.Errorf("got archived projects %+v", archived)
+	}
+
+	var commandErr *todoist.CommandError
+	if err := client.ArchiveProject(inbox); !errors.As(err, &commandErr) {
+		t.Errorf("archiving the Inbox: got %v, want a *CommandError", err)
+	}
+}
+
+func TestCompletedTasks(t *testing.T) {
+	server := NewServer()
+	defer server.Close()
+
+	client := server.Client()
+
+	task, err := client.AddTask(todoist.AddTaskArgs{Content: "Water plants"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := client.CloseTask(task.ID); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := client.GetTask(task.ID); !errors.Is(err, todoist.ErrNotFound) {
+		t.Errorf("GetTask of a completed task: got %v, want ErrNotFound", err)
+	}
+
+	info, err := client.GetTaskInfo(task.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if info.ID != task.ID || !info.IsCompleted || info.Content != "Water plants" {
+		t.Errorf("GetTaskInfo = %+v", info)
+	}
+
+	if err := client.ReopenTask(task.ID); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := client.GetTask(task.ID); err != nil {
+		t.Errorf("GetTask of a reopened task: %v", err)
+	}
+
+	if _, err := client.GetTaskInfo("404"); !errors.Is(err, todoist.ErrNotFound) {
+		t.Errorf("GetTaskInfo of an unknown task: got %v, want ErrNotFound", err)
+	}
+}
+
+func TestMoveTaskCycle(t *testing.T) {
+	server := NewServer()
+	defer server.Close()
+
+	client := server.Client()
+
+	batch := client.NewBatch()
+	parentID := batch.AddTask(todoist.AddTaskArgs{Content: "Parent"})
+	childID := batch.AddTask(todoist.AddTaskArgs{Content: "Child", ParentID: parentID})
+	grandchildID := batch.AddTask(todoist.AddTaskArgs{Content: "Grandchild", ParentID: childID})
+
+	result, err := batch.Commit()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := result.Err(); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, target := range []string{parentID, childID, grandchildID} {
+		err := client.MoveTask(result.ID(parentID), todoist.MoveTaskArgs{ParentID: result.ID(target)})
+
+		var commandErr *todoist.CommandError
+		if !errors.As(err, &commandErr) || commandErr.Code != http.StatusBadRequest {
+			t.Errorf("moving under %s: got %v, want a 400 *CommandError", target, err)
+		}
+	}
+
+	if err := client.MoveTask(result.ID(grandchildID), todoist.MoveTaskArgs{ParentID: result.ID(parentID)}); err != nil {
+		t.Errorf("moving a subtask up: %v", err)
+	}
+}
+
+func TestSyncUnsupported(t *testing.T) {
+	server := NewServer()
+	defer server.Close()
+
+	client := server.Client()
+
+	_, err := client.QuickAddTask(todoist.QuickAddTaskArgs{Text: "Pay rent tomorrow"})
+
+	var apiErr *todoist.APIError
+	if !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusNotImplemented {
+		t.Errorf("QuickAddTask: got %v, want 501", err)
+	}
+
+	if _, err := client.Sync(todoist.SyncArgs{}); !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusNotImplemented {
+		t.Errorf("Sync: got %v, want 501", err)
+	}
+}
@@ -0,0 +1,413 @@
+package todoisttest
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+
+	todoist "github.com/felipeornelis/todoist-go-client"
+)
+
+// The Sync API is served as far as the commands and lookups sent by the
+// client go. Reading resources, quick add and completed tasks answer 501, so
+// code relying on them fails loudly instead of reaching the real API.
+
+type syncCommand struct {
+	Type   string         `json:"type"`
+	UUID   string         `json:"uuid"`
+	TempID string         `json:"temp_id"`
+	Args   map[string]any `json:"args"`
+}
+
+type syncCommandError struct {
+	// Code is the HTTP status the REST API would have answered with, not one
+	// of Todoist's Sync error codes.
+	Code    int    `json:"error_code"`
+	Message string `json:"error"`
+}
+
+type syncProject struct {
+	ID           string `json:"id"`
+	Name         string `json:"name"`
+	Color        string `json:"color"`
+	ParentID     string `json:"parent_id"`
+	ChildOrder   int    `json:"child_order"`
+	Shared       bool   `json:"shared"`
+	IsFavorite   bool   `json:"is_favorite"`
+	InboxProject bool   `json:"inbox_project"`
+	ViewStyle    string `json:"view_style"`
+	IsArchived   bool   `json:"is_archived"`
+}
+
+type syncDue struct {
+	Date        string `json:"date"`
+	Timezone    string `json:"timezone,omitempty"`
+	String      string `json:"string"`
+	IsRecurring bool   `json:"is_recurring"`
+}
+
+type syncItem struct {
+	ID             string                `json:"id"`
+	ProjectID      string                `json:"project_id"`
+	SectionID      string                `json:"section_id"`
+	ParentID       string                `json:"parent_id"`
+	Content        string                `json:"content"`
+	Description    string                `json:"description"`
+	Priority       uint8                 `json:"priority"`
+	Due            *syncDue              `json:"due"`
+	Duration       *todoist.TaskDuration `json:"duration"`
+	ChildOrder     int                   `json:"child_order"`
+	Labels         []string              `json:"labels"`
+	AssignedByUID  string                `json:"assigned_by_uid"`
+	ResponsibleUID string                `json:"responsible_uid"`
+	Checked        bool                  `json:"checked"`
+	AddedAt        todoist.DateTime      `json:"added_at"`
+	NoteCount      int                   `json:"note_count"`
+}
+
+func toSyncItem(task *todoist.Task) syncItem {
+	item := syncItem{
+		ID:             task.ID,
+		ProjectID:      task.ProjectID,
+		SectionID:      task.SectionID,
+		ParentID:       task.ParentID,
+		Content:        task.Content,
+		Description:    task.Description,
+		Priority:       task.Priority,
+		ChildOrder:     task.Order,
+		Labels:         task.Labels,
+		AssignedByUID:  task.AssignerID,
+		ResponsibleUID: task.AssigneeID,
+		Checked:        task.IsCompleted,
+		AddedAt:        task.CreatedAt,
+		NoteCount:      task.CommentCount,
+	}
+
+	switch {
+	case !task.Due.Datetime.IsZero():
+		item.Due = &syncDue{Date: task.Due.Datetime.String(), Timezone: task.Due.Timezone, String: task.Due.String, IsRecurring: task.Due.IsRecurring}
+	case !task.Due.Date.IsZero():
+		item.Due = &syncDue{Date: task.Due.Date.String(), String: task.Due.String, IsRecurring: task.Due.IsRecurring}
+	}
+
+	if task.Duration.Amount > 0 {
+		duration := task.Duration
+		item.Duration = &duration
+	}
+
+	return item
+}
+
+// commandIDArgs are the arguments that may hold a temporary ID.
+var commandIDArgs = []string{"id", "project_id", "section_id", "parent_id"}
+
+func (s *Server) serveSync(w http.ResponseWriter, r *http.Request, path string) {
+	if r.Method != http.MethodPost && r.Method != http.MethodGet {
+		writeMethodNotAllowed(w)
+		return
+	}
+
+	if err := r.ParseForm(); err != nil {
+		writeError(w, http.StatusBadRequest, "Invalid form body")
+		return
+	}
+
+	switch path {
+	case "/sync":
+		if r.Form.Get("commands") == "" {
+			writeError(w, http.StatusNotImplemented, "Reading resources is not supported by todoisttest")
+			return
+		}
+		s.runCommands(w, r.Form.Get("commands"))
+	case "/projects/get_archived":
+		projects := []syncProject{}
+		for _, id := range sortedIDs(s.projects) {
+			if project := s.projects[id]; project.IsArchived {
+				projects = append(projects, syncProject{
+					ID:           project.ID,
+					Name:         project.Name,
+					Color:        project.Color,
+					ParentID:     project.ParentID,
+					ChildOrder:   project.Order,
+					Shared:       project.IsShared,
+					IsFavorite:   project.IsFavorite,
+					InboxProject: project.IsInboxProject,
+					ViewStyle:    project.ViewStyle,
+					IsArchived:   true,
+				})
+			}
+		}
+		writeJSON(w, projects)
+	case "/items/get":
+		// Unlike GET /tasks/{id}, the lookup finds completed tasks too.
+		task, ok := s.tasks[r.Form.Get("item_id")]
+		if !ok {
+			writeError(w, http.StatusNotFound, "Item not found")
+			return
+		}
+		writeJSON(w, map[string]any{"item": toSyncItem(task)})
+	case "/quick/add", "/completed/get_all":
+		writeError(w, http.StatusNotImplemented, fmt.Sprintf("%s is not supported by todoisttest", path))
+	default:
+		writeError(w, http.StatusNotFound, "Not found")
+	}
+}
+
+func (s *Server) runCommands(w http.ResponseWriter, encoded string) {
+	var commands []syncCommand
+	if err := json.Unmarshal([]byte(encoded), &commands); err != nil {
+		writeError(w, http.StatusBadRequest, "Invalid commands")
+		return
+	}
+
+	status := map[string]any{}
+	mapping := map[string]string{}
+
+	for _, command := range commands {
+		for _, key := range commandIDArgs {
+			if id, ok := command.Args[key].(string); ok && mapping[id] != "" {
+				command.Args[key] = mapping[id]
+			}
+		}
+
+		id, err := s.runCommand(command)
+		if err != nil {
+			status[command.UUID] = err
+			continue
+		}
+
+		status[command.UUID] = "ok"
+		if command.TempID != "" && id != "" {
+			mapping[command.TempID] = id
+		}
+	}
+
+	writeJSON(w, map[string]any{
+		"sync_token":      fmt.Sprintf("todoisttest-%d", s.lastID),
+		"sync_status":     status,
+		"temp_id_mapping": mapping,
+	})
+}
+
+// runCommand applies a command and returns the ID of the resource it
+// created, if any.
+func (s *Server) runCommand(command syncCommand) (string, *syncCommandError) {
+	args := command.Args
+	id, _ := args["id"].(string)
+
+	switch command.Type {
+	case "project_add":
+		return s.restCommand(s.addProject, args)
+	case "project_update":
+		return s.restCommand(func(w http.ResponseWriter, r *http.Request) { s.updateProject(w, r, id) }, withoutID(args))
+	case "section_add":
+		renameArg(args, "section_order", "order")
+		return s.restCommand(s.addSection, args)
+	case "item_add":
+		return s.restCommand(s.addTask, restTaskArgs(args))
+	case "item_update":
+		return s.restCommand(func(w http.ResponseWriter, r *http.Request) { s.updateTask(w, r, id) }, restTaskArgs(withoutID(args)))
+	case "item_close":
+		task, ok := s.tasks[id]
+		if !ok {
+			return "", &syncCommandError{Code: http.StatusNotFound, Message: "Task not found"}
+		}
+		task.IsCompleted = true
+	case "item_move":
+		return "", s.moveTask(id, args)
+	case "section_move":
+		return "", s.moveSection(id, args)
+	case "section_reorder":
+		sections, _ := args["sections"].([]any)
+		for _, value := range sections {
+			order, _ := value.(map[string]any)
+			section, ok := s.sections[fmt.Sprint(order["id"])]
+			if !ok {
+				return "", &syncCommandError{Code: http.StatusNotFound, Message: "Section not found"}
+			}
+			if position, ok := order["section_order"].(float64); ok {
+				section.Order = int(position)
+			}
+		}
+	case "project_archive", "project_unarchive":
+		project, ok := s.projects[id]
+		if !ok {
+			return "", &syncCommandError{Code: http.StatusNotFound, Message: "Project not found"}
+		}
+		if project.IsInboxProject {
+			return "", &syncCommandError{Code: http.StatusBadRequest, Message: "The Inbox project cannot be archived"}
+		}
+		project.IsArchived = command.Type == "project_archive"
+	default:
+		return "", &syncCommandError{Code: http.StatusNotImplemented, Message: fmt.Sprintf("%s is not supported by todoisttest", command.Type)}
+	}
+
+	return "", nil
+}
+
+// restCommand runs a REST handler with args as its JSON body, so commands
+// share the validation of their REST counterpart.
+func (s *Server) restCommand(handler http.HandlerFunc, args map[string]any) (string, *syncCommandError) {
+	body, err := json.Marshal(args)
+	if err != nil {
+		return "", &syncCommandError{Code: http.StatusBadRequest, Message: err.Error()}
+	}
+
+	recorder := httptest.NewRecorder()
+	handler(recorder, httptest.NewRequest(http.MethodPost, "/", bytes.NewReader(body)))
+
+	if recorder.Code != http.StatusOK {
+		return "", &syncCommandError{Code: recorder.Code, Message: strings.TrimSpace(recorder.Body.String())}
+	}
+
+	var created struct {
+		ID string `json:"id"`
+	}
+	json.Unmarshal(recorder.Body.Bytes(), &created)
+
+	return created.ID, nil
+}
+
+func (s *Server) moveTask(id string, args map[string]any) *syncCommandError {
+	task, ok := s.tasks[id]
+	if !ok {
+		return &syncCommandError{Code: http.StatusNotFound, Message: "Task not found"}
+	}
+
+	projectID, sectionID, parentID := stringArg(args, "project_id"), stringArg(args, "section_id"), stringArg(args, "parent_id")
+
+	switch {
+	case projectID != "":
+		if _, ok := s.projects[projectID]; !ok {
+			return &syncCommandError{Code: http.StatusBadRequest, Message: "Project not found"}
+		}
+		sectionID = ""
+	case sectionID != "":
+		section, ok := s.sections[sectionID]
+		if !ok {
+			return &syncCommandError{Code: http.StatusBadRequest, Message: "Section not found"}
+		}
+		projectID = section.ProjectID
+	case parentID != "":
+		parent, ok := s.tasks[parentID]
+		if !ok {
+			return &syncCommandError{Code: http.StatusBadRequest, Message: "Parent task not found"}
+		}
+		if s.isDescendant(parentID, id) {
+			return &syncCommandError{Code: http.StatusBadRequest, Message: "A task cannot be moved under itself or its subtasks"}
+		}
+		projectID, sectionID = parent.ProjectID, parent.SectionID
+	default:
+		return &syncCommandError{Code: http.StatusBadRequest, Message: "Required argument is missing: project_id, section_id or parent_id"}
+	}
+
+	task.ParentID = parentID
+	s.relocateTask(task, projectID, sectionID)
+
+	return nil
+}
+
+// isDescendant reports whether the task id is ancestorID or one of its
+// subtasks, walking up the parents of id. It must be called with s.mu held.
+func (s *Server) isDescendant(id, ancestorID string) bool {
+	seen := map[string]bool{}
+	for id != "" && !seen[id] {
+		if id == ancestorID {
+			return true
+		}
+		seen[id] = true
+
+		task, ok := s.tasks[id]
+		if !ok {
+			return false
+		}
+		id = task.ParentID
+	}
+
+	return false
+}
+
+// relocateTask moves a task and its subtasks. It must be called with s.mu
+// held.
+func (s *Server) relocateTask(task *todoist.Task, projectID, sectionID string) {
+	task.ProjectID = projectID
+	task.SectionID = sectionID
+
+	for _, child := range s.tasks {
+		if child.ParentID == task.ID {
+			s.relocateTask(child, projectID, sectionID)
+		}
+	}
+}
+
+func (s *Server) moveSection(id string, args map[string]any) *syncCommandError {
+	section, ok := s.sections[id]
+	if !ok {
+		return &syncCommandError{Code: http.StatusNotFound, Message: "Section not found"}
+	}
+
+	projectID := stringArg(args, "project_id")
+	if _, ok := s.projects[projectID]; !ok {
+		return &syncCommandError{Code: http.StatusBadRequest, Message: "Project not found"}
+	}
+
+	section.ProjectID = projectID
+	for _, task := range s.tasks {
+		if task.SectionID == id {
+			task.ProjectID = projectID
+		}
+	}
+
+	return nil
+}
+
+// restTaskArgs converts item_add and item_update arguments to the REST ones.
+func restTaskArgs(args map[string]any) map[string]any {
+	renameArg(args, "child_order", "order")
+	renameArg(args, "responsible_uid", "assignee_id")
+
+	if due, ok := args["due"].(map[string]any); ok {
+		delete(args, "due")
+		switch date := stringArg(due, "date"); {
+		case due["string"] != nil:
+			args["due_string"] = due["string"]
+			args["due_lang"] = due["lang"]
+		case strings.Contains(date, "T"):
+			args["due_datetime"] = date
+		case date != "":
+			args["due_date"] = date
+		}
+	}
+
+	if duration, ok := args["duration"].(map[string]any); ok {
+		args["duration"] = duration["amount"]
+		args["duration_unit"] = duration["unit"]
+	}
+
+	return args
+}
+
+func renameArg(args map[string]any, from, to string) {
+	if value, ok := args[from]; ok {
+		delete(args, from)
+		args[to] = value
+	}
+}
+
+func withoutID(args map[string]any) map[string]any {
+	copied := make(map[string]any, len(args))
+	for key, value := range args {
+		if key != "id" {
+			copied[key] = value
+		}
+	}
+	return copied
+}
+
+func stringArg(args map[string]any, key string) string {
+	value, _ := args[key].(string)
+	return value
+}
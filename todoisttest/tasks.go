@@ -0,0 +1,280 @@
+package todoisttest
+
+import (
+	"net/http"
+	"strings"
+	"time"
+
+	todoist "github.com/felipeornelis/todoist-go-client"
+)
+
+func (s *Server) serveTasks(w http.ResponseWriter, r *http.Request, parts []string) {
+	switch {
+	case len(parts) == 0 && r.Method == http.MethodGet:
+		s.listTasks(w, r)
+	case len(parts) == 0 && r.Method == http.MethodPost:
+		s.addTask(w, r)
+	case len(parts) == 1 && r.Method == http.MethodGet:
+		// Like the REST API, only active tasks are found.
+		if task, ok := s.tasks[parts[0]]; ok && !task.IsCompleted {
+			writeJSON(w, task)
+		} else {
+			writeError(w, http.StatusNotFound, "Task not found")
+		}
+	case len(parts) == 1 && r.Method == http.MethodPost:
+		s.updateTask(w, r, parts[0])
+	case len(parts) == 1 && r.Method == http.MethodDelete:
+		if _, ok := s.findTask(w, parts[0]); ok {
+			s.deleteTask(parts[0])
+			w.WriteHeader(http.StatusNoContent)
+		}
+	case len(parts) == 2 && r.Method == http.MethodPost && (parts[1] == "close" || parts[1] == "reopen"):
+		if task, ok := s.findTask(w, parts[0]); ok {
+			task.IsCompleted = parts[1] == "close"
+			w.WriteHeader(http.StatusNoContent)
+		}
+	case len(parts) <= 2:
+		writeMethodNotAllowed(w)
+	default:
+		writeError(w, http.StatusNotFound, "Not found")
+	}
+}
+
+func (s *Server) findTask(w http.ResponseWriter, id string) (*todoist.Task, bool) {
+	task, ok := s.tasks[id]
+	if !ok {
+		writeError(w, http.StatusNotFound, "Task not found")
+	}
+
+	return task, ok
+}
+
+func (s *Server) listTasks(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+
+	if query.Get("filter") != "" {
+		writeError(w, http.StatusBadRequest, "Filter queries are not supported by todoisttest")
+		return
+	}
+
+	var ids map[string]bool
+	if value := query.Get("ids"); value != "" {
+		ids = map[string]bool{}
+		for _, id := range strings.Split(value, ",") {
+			ids[id] = true
+		}
+	}
+
+	tasks := []todoist.Task{}
+	for _, id := range sortedIDs(s.tasks) {
+		task := s.tasks[id]
+
+		switch {
+		case task.IsCompleted:
+		case ids != nil && !ids[task.ID]:
+		case query.Get("project_id") != "" && task.ProjectID != query.Get("project_id"):
+		case query.Get("section_id") != "" && task.SectionID != query.Get("section_id"):
+		case query.Get("label") != "" && !contains(task.Labels, query.Get("label")):
+		default:
+			tasks = append(tasks, *task)
+		}
+	}
+
+	writeJSON(w, tasks)
+}
+
+func (s *Server) addTask(w http.ResponseWriter, r *http.Request) {
+	var args todoist.AddTaskArgs
+	if !decodeBody(w, r, &args) {
+		return
+	}
+
+	if args.Content == "" {
+		writeMissingArgument(w, "content")
+		return
+	}
+
+	task := &todoist.Task{
+		ProjectID:   args.ProjectID,
+		SectionID:   args.SectionID,
+		ParentID:    args.ParentID,
+		Content:     args.Content,
+		Description: args.Description,
+		Labels:      args.Labels,
+		Order:       args.Order,
+		Priority:    args.Priority,
+		AssigneeID:  args.AssigneeID,
+		CreatedAt:   todoist.NewDateTime(time.Now().UTC()),
+	}
+
+	if task.Labels == nil {
+		task.Labels = []string{}
+	}
+
+	if task.Priority == 0 {
+		task.Priority = 1
+	}
+
+	if args.ParentID != "" {
+		parent, ok := s.tasks[args.ParentID]
+		if !ok {
+			writeError(w, http.StatusBadRequest, "Parent task not found")
+			return
+		}
+		task.ProjectID = parent.ProjectID
+		task.SectionID = parent.SectionID
+	}
+
+	if args.SectionID != "" {
+		section, ok := s.sections[args.SectionID]
+		if !ok {
+			writeError(w, http.StatusBadRequest, "Section not found")
+			return
+		}
+		task.ProjectID = section.ProjectID
+	}
+
+	if task.ProjectID == "" {
+		for _, project := range s.projects {
+			if project.IsInboxProject {
+				task.ProjectID = project.ID
+			}
+		}
+	}
+
+	if _, ok := s.projects[task.ProjectID]; !ok {
+		writeError(w, http.StatusBadRequest, "Project not found")
+		return
+	}
+
+	if !s.applyTaskSchedule(w, task, args.Priority, args.DueString, args.DueDate, args.DueDatetime, args.Duration, args.DurationUnit) {
+		return
+	}
+
+	task.ID = s.nextID()
+	task.URL = taskURL(task.ID)
+	s.tasks[task.ID] = task
+
+	writeJSON(w, task)
+}
+
+func (s *Server) updateTask(w http.ResponseWriter, r *http.Request, id string) {
+	task, ok := s.findTask(w, id)
+	if !ok {
+		return
+	}
+
+	var args todoist.UpdateTaskArgs
+	if !decodeBody(w, r, &args) {
+		return
+	}
+
+	updated := *task
+
+	if args.Content != "" {
+		updated.Content = args.Content
+	}
+	if args.Description != "" {
+		updated.Description = args.Description
+	}
+	if args.Labels != nil {
+		updated.Labels = args.Labels
+	}
+	if args.Priority != 0 {
+		updated.Priority = args.Priority
+	}
+	if args.AssigneeID != "" {
+		updated.AssigneeID = args.AssigneeID
+	}
+
+	if !s.applyTaskSchedule(w, &updated, args.Priority, args.DueString, args.DueDate, args.DueDatetime, args.Duration, args.DurationUnit) {
+		return
+	}
+
+	*task = updated
+
+	writeJSON(w, task)
+}
+
+// applyTaskSchedule validates and applies the priority, due and duration
+// arguments shared by task creation and update.
+func (s *Server) applyTaskSchedule(w http.ResponseWriter, task *todoist.Task, priority uint8, dueString string, dueDate *todoist.Date, dueDatetime *todoist.DateTime, duration uint, durationUnit string) bool {
+	if priority > 4 {
+		writeError(w, http.StatusBadRequest, "Invalid argument value: priority")
+		return false
+	}
+
+	set := 0
+	if dueString != "" {
+		set++
+	}
+	if dueDate != nil {
+		set++
+	}
+	if dueDatetime != nil {
+		set++
+	}
+	if set > 1 {
+		writeError(w, http.StatusBadRequest, "Only one of due_string, due_date or due_datetime can be set")
+		return false
+	}
+
+	switch {
+	case dueString != "":
+		task.Due = todoist.TaskDue{String: dueString}
+	case dueDate != nil:
+		task.Due = todoist.TaskDue{String: dueDate.String(), Date: *dueDate}
+	case dueDatetime != nil:
+		task.Due = todoist.TaskDue{
+			String:   dueDatetime.String(),
+			Date:     todoist.NewDate(dueDatetime.Year(), dueDatetime.Month(), dueDatetime.Day()),
+			Datetime: *dueDatetime,
+		}
+		if !dueDatetime.Floating {
+			task.Due.Timezone = "UTC"
+		}
+	}
+
+	if (duration == 0) != (durationUnit == "") {
+		writeError(w, http.StatusBadRequest, "duration and duration_unit must be set together")
+		return false
+	}
+
+	if duration != 0 {
+		if durationUnit != "minute" && durationUnit != "day" {
+			writeError(w, http.StatusBadRequest, "Invalid argument value: duration_unit")
+			return false
+		}
+		task.Duration = todoist.TaskDuration{Amount: duration, Unit: durationUnit}
+	}
+
+	return true
+}
+
+// deleteTask removes the task with its subtasks and comments. It must be
+// called with s.mu held.
+func (s *Server) deleteTask(id string) {
+	delete(s.tasks, id)
+
+	for childID, child := range s.tasks {
+		if child.ParentID == id {
+			s.deleteTask(childID)
+		}
+	}
+
+	for commentID, comment := range s.comments {
+		if comment.TaskID == id {
+			delete(s.comments, commentID)
+		}
+	}
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+
+	return false
+}
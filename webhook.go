@@ -0,0 +1,299 @@
+package todoist
+
+import (
+	"container/list"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+)
+
+const (
+	EVENT_ITEM_ADDED         = "item:added"
+	EVENT_ITEM_UPDATED       = "item:updated"
+	EVENT_ITEM_DELETED       = "item:deleted"
+	EVENT_ITEM_COMPLETED     = "item:completed"
+	EVENT_ITEM_UNCOMPLETED   = "item:uncompleted"
+	EVENT_NOTE_ADDED         = "note:added"
+	EVENT_NOTE_UPDATED       = "note:updated"
+	EVENT_NOTE_DELETED       = "note:deleted"
+	EVENT_PROJECT_ADDED      = "project:added"
+	EVENT_PROJECT_UPDATED    = "project:updated"
+	EVENT_PROJECT_DELETED    = "project:deleted"
+	EVENT_PROJECT_ARCHIVED   = "project:archived"
+	EVENT_PROJECT_UNARCHIVED = "project:unarchived"
+	EVENT_SECTION_ADDED      = "section:added"
+	EVENT_SECTION_UPDATED    = "section:updated"
+	EVENT_SECTION_DELETED    = "section:deleted"
+	EVENT_SECTION_ARCHIVED   = "section:archived"
+	EVENT_SECTION_UNARCHIVED = "section:unarchived"
+	EVENT_LABEL_ADDED        = "label:added"
+	EVENT_LABEL_UPDATED      = "label:updated"
+	EVENT_LABEL_DELETED      = "label:deleted"
+
+	WEBHOOK_SIGNATURE_HEADER   = "X-Todoist-Hmac-SHA256"
+	WEBHOOK_DELIVERY_ID_HEADER = "X-Todoist-Delivery-ID"
+
+	maxWebhookBodySize     = 1 << 20
+	webhookDeliveriesCache = 1024
+)
+
+type WebhookInitiator struct {
+	ID       string `json:"id"`
+	Email    string `json:"email"`
+	FullName string `json:"full_name"`
+}
+
+// WebhookEvent is the envelope shared by every event. Data holds the raw
+// event_data, decoded by the typed events below.
+type WebhookEvent struct {
+	Name        string           `json:"event_name"`
+	UserID      string           `json:"user_id"`
+	Initiator   WebhookInitiator `json:"initiator"`
+	Version     string           `json:"version"`
+	TriggeredAt DateTime         `json:"triggered_at"`
+	Data        json.RawMessage  `json:"event_data"`
+	DeliveryID  string           `json:"-"`
+}
+
+// Resource returns the part of the event name before the colon, e.g. "item".
+func (e WebhookEvent) Resource() string {
+	resource, _, _ := strings.Cut(e.Name, ":")
+	return resource
+}
+
+type TaskEvent struct {
+	WebhookEvent
+	Task Task
+}
+
+type CommentEvent struct {
+	WebhookEvent
+	Comment Comment
+}
+
+type ProjectEvent struct {
+	WebhookEvent
+	Project Project
+}
+
+type SectionEvent struct {
+	WebhookEvent
+	Section Section
+}
+
+type LabelEvent struct {
+	WebhookEvent
+	Label Label
+}
+
+// WebhookHandler receives Todoist webhooks. It rejects requests whose
+// signature does not match the app's client secret, decodes the events and
+// dispatches them to the registered callbacks. Deliveries already handled
+// successfully are acknowledged without being dispatched again.
+type WebhookHandler struct {
+	secret []byte
+
+	mu       sync.RWMutex
+	handlers map[string][]func(context.Context, WebhookEvent) error
+
+	deliveriesMu sync.Mutex
+	deliveries   map[string]*list.Element
+	order        *list.List
+}
+
+func NewWebhookHandler(clientSecret string) *WebhookHandler {
+	return &WebhookHandler{
+		secret:     []byte(clientSecret),
+		handlers:   map[string][]func(context.Context, WebhookEvent) error{},
+		deliveries: map[string]*list.Element{},
+		order:      list.New(),
+	}
+}
+
+// OnEvent registers fn for the event name, or for every event when name is
+// "*".
+func (h *WebhookHandler) OnEvent(name string, fn func(context.Context, WebhookEvent) error) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.handlers[name] = append(h.handlers[name], fn)
+}
+
+// OnTask registers fn for an item:* event.
+func (h *WebhookHandler) OnTask(name string, fn func(context.Context, TaskEvent) error) {
+	h.OnEvent(name, func(ctx context.Context, event WebhookEvent) error {
+		var item syncItem
+		if err := json.Unmarshal(event.Data, &item); err != nil {
+			return err
+		}
+
+		task, err := item.toTask()
+		if err != nil {
+			return err
+		}
+
+		return fn(ctx, TaskEvent{WebhookEvent: event, Task: task})
+	})
+}
+
+// OnComment registers fn for a note:* event.
+func (h *WebhookHandler) OnComment(name string, fn func(context.Context, CommentEvent) error) {
+	h.OnEvent(name, func(ctx context.Context, event WebhookEvent) error {
+		var note syncNote
+		if err := json.Unmarshal(event.Data, &note); err != nil {
+			return err
+		}
+
+		return fn(ctx, CommentEvent{WebhookEvent: event, Comment: note.toComment()})
+	})
+}
+
+// OnProject registers fn for a project:* event.
+func (h *WebhookHandler) OnProject(name string, fn func(context.Context, ProjectEvent) error) {
+	h.OnEvent(name, func(ctx context.Context, event WebhookEvent) error {
+		var project syncProject
+		if err := json.Unmarshal(event.Data, &project); err != nil {
+			return err
+		}
+
+		return fn(ctx, ProjectEvent{WebhookEvent: event, Project: project.toProject()})
+	})
+}
+
+// OnSection registers fn for a section:* event.
+func (h *WebhookHandler) OnSection(name string, fn func(context.Context, SectionEvent) error) {
+	h.OnEvent(name, func(ctx context.Context, event WebhookEvent) error {
+		var section syncSection
+		if err := json.Unmarshal(event.Data, &section); err != nil {
+			return err
+		}
+
+		return fn(ctx, SectionEvent{WebhookEvent: event, Section: section.toSection()})
+	})
+}
+
+// OnLabel registers fn for a label:* event.
+func (h *WebhookHandler) OnLabel(name string, fn func(context.Context, LabelEvent) error) {
+	h.OnEvent(name, func(ctx context.Context, event WebhookEvent) error {
+		var label syncLabel
+		if err := json.Unmarshal(event.Data, &label); err != nil {
+			return err
+		}
+
+		return fn(ctx, LabelEvent{WebhookEvent: event, Label: label.toLabel()})
+	})
+}
+
+// VerifyWebhookSignature reports whether signature is the base64-encoded
+// HMAC-SHA256 of body keyed with the client secret.
+func VerifyWebhookSignature(clientSecret string, body []byte, signature string) bool {
+	mac := hmac.New(sha256.New, []byte(clientSecret))
+	mac.Write(body)
+
+	expected := base64.StdEncoding.EncodeToString(mac.Sum(nil))
+
+	return hmac.Equal([]byte(expected), []byte(signature))
+}
+
+func (h *WebhookHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	body, err := io.ReadAll(io.LimitReader(r.Body, maxWebhookBodySize+1))
+	if err != nil {
+		http.Error(w, "cannot read body", http.StatusBadRequest)
+		return
+	}
+
+	if len(body) > maxWebhookBodySize {
+		http.Error(w, "body too large", http.StatusRequestEntityTooLarge)
+		return
+	}
+
+	if !VerifyWebhookSignature(string(h.secret), body, r.Header.Get(WEBHOOK_SIGNATURE_HEADER)) {
+		http.Error(w, "invalid signature", http.StatusUnauthorized)
+		return
+	}
+
+	var event WebhookEvent
+	if err := json.Unmarshal(body, &event); err != nil {
+		http.Error(w, "invalid event", http.StatusBadRequest)
+		return
+	}
+	event.DeliveryID = r.Header.Get(WEBHOOK_DELIVERY_ID_HEADER)
+
+	if event.DeliveryID != "" && !h.claim(event.DeliveryID) {
+		w.WriteHeader(http.StatusOK)
+		return
+	}
+
+	if err := h.dispatch(r.Context(), event); err != nil {
+		if event.DeliveryID != "" {
+			h.release(event.DeliveryID)
+		}
+
+		// Todoist retries deliveries answered with an error.
+		http.Error(w, fmt.Sprintf("cannot handle %s event", event.Name), http.StatusInternalServerError)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+}
+
+func (h *WebhookHandler) dispatch(ctx context.Context, event WebhookEvent) error {
+	h.mu.RLock()
+	handlers := append(append([]func(context.Context, WebhookEvent) error(nil), h.handlers[event.Name]...), h.handlers["*"]...)
+	h.mu.RUnlock()
+
+	for _, handler := range handlers {
+		if err := handler(ctx, event); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// claim records the delivery ID before its events are dispatched, so
+// concurrent redeliveries are not dispatched twice. It reports false if the
+// delivery was already claimed. The most recent IDs are kept, evicting the
+// oldest ones.
+func (h *WebhookHandler) claim(deliveryID string) bool {
+	h.deliveriesMu.Lock()
+	defer h.deliveriesMu.Unlock()
+
+	if _, ok := h.deliveries[deliveryID]; ok {
+		return false
+	}
+
+	h.deliveries[deliveryID] = h.order.PushBack(deliveryID)
+
+	if h.order.Len() > webhookDeliveriesCache {
+		oldest := h.order.Front()
+		h.order.Remove(oldest)
+		delete(h.deliveries, oldest.Value.(string))
+	}
+
+	return true
+}
+
+// release forgets a delivery whose dispatch failed, so Todoist's retry is
+// dispatched again.
+func (h *WebhookHandler) release(deliveryID string) {
+	h.deliveriesMu.Lock()
+	defer h.deliveriesMu.Unlock()
+
+	if element, ok := h.deliveries[deliveryID]; ok {
+		h.order.Remove(element)
+		delete(h.deliveries, deliveryID)
+	}
+}
@@ -0,0 +1,124 @@
+package todoist
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+)
+
+const testWebhookSecret = "client-secret"
+
+func webhookRequest(body, deliveryID string) *http.Request {
+	mac := hmac.New(sha256.New, []byte(testWebhookSecret))
+	mac.Write([]byte(body))
+
+	request := httptest.NewRequest(http.MethodPost, "/webhook", strings.NewReader(body))
+	request.Header.Set(WEBHOOK_SIGNATURE_HEADER, base64.StdEncoding.EncodeToString(mac.Sum(nil)))
+	request.Header.Set(WEBHOOK_DELIVERY_ID_HEADER, deliveryID)
+
+	return request
+}
+
+func serveWebhook(handler http.Handler, request *http.Request) int {
+	recorder := httptest.NewRecorder()
+	handler.ServeHTTP(recorder, request)
+	return recorder.Code
+}
+
+const testTaskEvent = `{"event_name":"item:added","user_id":"2671355","event_data":{"id":"2995104339","content":"Buy Milk"}}`
+
+func TestWebhookSignature(t *testing.T) {
+	handler := NewWebhookHandler(testWebhookSecret)
+
+	request := webhookRequest(testTaskEvent, "1")
+	request.Header.Set(WEBHOOK_SIGNATURE_HEADER, "forged")
+
+	if code := serveWebhook(handler, request); code != http.StatusUnauthorized {
+		t.Errorf("got %d, want 401", code)
+	}
+}
+
+func TestWebhookBodyTooLarge(t *testing.T) {
+	handler := NewWebhookHandler(testWebhookSecret)
+
+	body := `{"event_name":"item:added","event_data":{"content":"` + strings.Repeat("a", maxWebhookBodySize) + `"}}`
+
+	if code := serveWebhook(handler, webhookRequest(body, "1")); code != http.StatusRequestEntityTooLarge {
+		t.Errorf("got %d, want 413", code)
+	}
+}
+
+func TestWebhookDeduplicatesConcurrentDeliveries(t *testing.T) {
+	handler := NewWebhookHandler(testWebhookSecret)
+
+	var calls int32
+	started := make(chan struct{})
+	release := make(chan struct{})
+	handler.OnTask(EVENT_ITEM_ADDED, func(ctx context.Context, event TaskEvent) error {
+		atomic.AddInt32(&calls, 1)
+		close(started)
+		<-release
+		return nil
+	})
+
+	codes := make(chan int)
+	serve := func() { codes <- serveWebhook(handler, webhookRequest(testTaskEvent, "delivery-1")) }
+
+	go serve()
+	<-started
+
+	// Redeliveries arriving while the first one is dispatched are
+	// acknowledged without being dispatched.
+	const redeliveries = 7
+	for i := 0; i < redeliveries; i++ {
+		go serve()
+	}
+	for i := 0; i < redeliveries; i++ {
+		if code := <-codes; code != http.StatusOK {
+			t.Errorf("got %d, want 200", code)
+		}
+	}
+
+	close(release)
+	if code := <-codes; code != http.StatusOK {
+		t.Errorf("got %d, want 200", code)
+	}
+
+	if calls != 1 {
+		t.Errorf("dispatched %d times, want 1", calls)
+	}
+}
+
+func TestWebhookRetriesFailedDelivery(t *testing.T) {
+	handler := NewWebhookHandler(testWebhookSecret)
+
+	var calls int
+	handler.OnEvent("*", func(ctx context.Context, event WebhookEvent) error {
+		calls++
+		if calls == 1 {
+			return errors.New("database unavailable")
+		}
+		return nil
+	})
+
+	if code := serveWebhook(handler, webhookRequest(testTaskEvent, "delivery-1")); code != http.StatusInternalServerError {
+		t.Fatalf("got %d, want 500", code)
+	}
+	if code := serveWebhook(handler, webhookRequest(testTaskEvent, "delivery-1")); code != http.StatusOK {
+		t.Fatalf("got %d on retry, want 200", code)
+	}
+	if code := serveWebhook(handler, webhookRequest(testTaskEvent, "delivery-1")); code != http.StatusOK {
+		t.Fatalf("got %d on redelivery, want 200", code)
+	}
+
+	if calls != 2 {
+		t.Errorf("dispatched %d times, want 2", calls)
+	}
+}